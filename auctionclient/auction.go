@@ -0,0 +1,188 @@
+package auctionclient
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Auction makes the calls for one auction, as the bidder of the client
+type Auction struct {
+	client *Client
+	id     int32
+}
+
+// ID is the ID of the auction
+func (a *Auction) ID() int32 {
+	return a.id
+}
+
+// Bid bids the amount. a rejected bid is an error with a rejection, a retry of the bid on another server is
+// recognised by the servers, so it is never placed twice
+func (a *Auction) Bid(ctx context.Context, amount int32) (*proto.Ack, error) {
+	return a.place(ctx, amount, false)
+}
+
+// PlaceMaxBid places a secret maximum in an English auction, the servers bid for the bidder up to it
+func (a *Auction) PlaceMaxBid(ctx context.Context, maximum int32) (*proto.Ack, error) {
+	return a.place(ctx, maximum, true)
+}
+
+func (a *Auction) place(ctx context.Context, amount int32, maximum bool) (*proto.Ack, error) {
+	c := a.client
+	req := &proto.Amount{
+		Id:        c.ID(),
+		Amount:    amount,
+		Lamport:   c.tick(),
+		AuctionId: a.id,
+		RequestId: newRequestId(), //the same for every server that is tried
+	}
+	var ack *proto.Ack
+	err := c.call(ctx, func(ctx context.Context, server proto.AuctionClient) (err error) {
+		if maximum {
+			ack, err = server.PlaceMaxBid(c.outgoing(ctx), req)
+		} else {
+			ack, err = server.Bid(c.outgoing(ctx), req)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.receive(ack.Lamport)
+	return ack, nil
+}
+
+// Accept takes the current asking price of a Dutch auction. like a bid, a retry on another server is recognised, so
+// an acceptance that won is never answered as lost
+func (a *Auction) Accept(ctx context.Context) (*proto.Ack, error) {
+	c := a.client
+	req := &proto.Acceptance{Id: c.ID(), Lamport: c.tick(), AuctionId: a.id, RequestId: newRequestId()}
+	var ack *proto.Ack
+	err := c.call(ctx, func(ctx context.Context, server proto.AuctionClient) (err error) {
+		ack, err = server.Accept(c.outgoing(ctx), req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.receive(ack.Lamport)
+	return ack, nil
+}
+
+// Result is the current state of the auction, or how it ended
+func (a *Auction) Result(ctx context.Context) (*proto.Outcome, error) {
+	c := a.client
+	req := &proto.Empty{Lamport: c.tick(), AuctionId: a.id}
+	var outcome *proto.Outcome
+	err := c.call(ctx, func(ctx context.Context, server proto.AuctionClient) (err error) {
+		outcome, err = server.Result(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.receive(outcome.Lamport)
+	return outcome, nil
+}
+
+// History returns up to limit attempts to buy the item from offset on, oldest first. a limit of 0 means 20
+func (a *Auction) History(ctx context.Context, offset int32, limit int32) (*proto.HistoryPage, error) {
+	c := a.client
+	req := &proto.HistoryRequest{AuctionId: a.id, Lamport: c.tick(), Offset: offset, Limit: limit}
+	var page *proto.HistoryPage
+	err := c.call(ctx, func(ctx context.Context, server proto.AuctionClient) (err error) {
+		page, err = server.History(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.receive(page.Lamport)
+	return page, nil
+}
+
+// Watch is a stream of the changes of an auction
+type Watch struct {
+	Updates <-chan *proto.Update // closed when the stream ends
+	cancel  context.CancelFunc
+	err     error
+}
+
+// Stop ends the stream
+func (w *Watch) Stop() {
+	w.cancel()
+}
+
+// Err says why the stream ended once Updates is closed, nil if it was stopped or the auction closed
+func (w *Watch) Err() error {
+	return w.err
+}
+
+// Watch streams every change of the auction, or of every auction for ID 0, until ctx ends or Stop is called.
+// any server can stream them, so it works without the leader
+func (a *Auction) Watch(ctx context.Context) (*Watch, error) {
+	c := a.client
+	req := &proto.Empty{Lamport: c.tick(), AuctionId: a.id}
+	var stream grpc.ServerStreamingClient[proto.Update]
+	var cancel context.CancelFunc
+	err := c.call(ctx, func(_ context.Context, server proto.AuctionClient) error {
+		//the stream lives on after the call, so it gets its own context rather than the one of the attempt
+		var streamCtx context.Context
+		streamCtx, cancel = context.WithCancel(ctx)
+		var err error
+		stream, err = server.Watch(streamCtx, req)
+		if err == nil {
+			//the server sends its header once the watch has started, so a server that is down is noticed here.
+			//one that hangs gets attemptTimeout like any other call before the next one is tried
+			timer := time.AfterFunc(attemptTimeout, cancel)
+			var header metadata.MD
+			header, err = stream.Header()
+			if err == nil && header == nil {
+				//the stream ended without a header, the reason is in its status
+				if _, err = stream.Recv(); err == io.EOF {
+					err = status.Error(codes.Unavailable, "the watch ended before it started")
+				}
+			}
+			if !timer.Stop() && ctx.Err() == nil {
+				err = status.Errorf(codes.DeadlineExceeded, "the watch did not start within %v", attemptTimeout)
+			}
+		}
+		if err != nil {
+			cancel()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan *proto.Update)
+	w := &Watch{Updates: updates, cancel: cancel}
+	go func() {
+		defer close(updates)
+		defer cancel()
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && status.Code(err) != codes.Canceled {
+					w.err = err
+				}
+				return
+			}
+			c.receive(update.Lamport)
+			select {
+			case updates <- update:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+	return w, nil
+}
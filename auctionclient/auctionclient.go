@@ -0,0 +1,323 @@
+// Package auctionclient lets Go programs bid in the auctions of a cluster of auction servers.
+//
+// A Client registers a bidder (or reuses an earlier registration), keeps the Lamport clock, and moves to another
+// server of the cluster when the one it uses is down or not the leader, going straight to the leader if the server
+// names it. Auction gives the calls for one auction:
+//
+//	c, err := auctionclient.New("localhost:8080", "localhost:8081", "localhost:8082")
+//	...
+//	_, _, err = c.Register(ctx, "alice")
+//	ack, err := c.Auction(1).Bid(ctx, 100)
+//
+// Rejected requests are answered with an error, RejectionOf gives the reason the server gave for it.
+package auctionclient
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	crand "crypto/rand"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	attemptTimeout = 3 * time.Second        // how long one server gets to answer before the next one is tried
+	maxRounds      = 8                      // times every server is tried before giving up
+	backoffBase    = 100 * time.Millisecond // wait after the first round, doubled every round
+	backoffCap     = 2 * time.Second
+)
+
+// Config is what NewWithConfig needs to know, only Servers is required
+type Config struct {
+	Servers     []string                         // addresses of the servers of the cluster
+	Credentials credentials.TransportCredentials // how the connections are secured, nil for plain text
+	ID          int32                            // bidder ID from an earlier registration, 0 to call Register instead
+	Credential  string                           // issued together with the ID
+}
+
+// Client talks to a cluster of auction servers as one bidder. It can be used from several goroutines at once
+type Client struct {
+	servers []string
+	conns   []*grpc.ClientConn
+	clients []proto.AuctionClient
+
+	mu         sync.Mutex // guards the fields below
+	current    int        // index of the server in use
+	id         int32
+	credential string
+	lamport    int32
+}
+
+// New connects to the servers in plain text, see NewWithConfig
+func New(servers ...string) (*Client, error) {
+	return NewWithConfig(Config{Servers: servers})
+}
+
+// NewWithConfig prepares a connection to each server, they are only made once a server is used
+func NewWithConfig(cfg Config) (*Client, error) {
+	if len(cfg.Servers) == 0 {
+		return nil, errors.New("auctionclient: no servers given")
+	}
+	creds := cfg.Credentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	c := &Client{servers: cfg.Servers, id: cfg.ID, credential: cfg.Credential}
+	for _, address := range cfg.Servers {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.conns = append(c.conns, conn)
+		c.clients = append(c.clients, proto.NewAuctionClient(conn))
+	}
+	return c, nil
+}
+
+// Close closes the connections to the servers
+func (c *Client) Close() error {
+	var first error
+	for _, conn := range c.conns {
+		if err := conn.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// ID is the bidder ID the bids are made with, 0 before Register
+func (c *Client) ID() int32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.id
+}
+
+// Credential proves the bids are from the bidder, keep it to bid as the same bidder again
+func (c *Client) Credential() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.credential
+}
+
+// Lamport is the current time of the Lamport clock of the client
+func (c *Client) Lamport() int32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lamport
+}
+
+// Server is the address of the server in use
+func (c *Client) Server() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.servers[c.current]
+}
+
+// Register registers a new bidder that the later requests are made as, and returns its ID and credential
+func (c *Client) Register(ctx context.Context, name string) (int32, string, error) {
+	req := &proto.Registration{Name: name, Lamport: c.tick()}
+	var response *proto.Registered
+	err := c.callOnce(ctx, func(ctx context.Context, server proto.AuctionClient) (err error) {
+		response, err = server.Register(ctx, req)
+		return err
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	c.mu.Lock()
+	c.id = response.Id
+	c.credential = response.Credential
+	c.mu.Unlock()
+	c.receive(response.Lamport)
+	return response.Id, response.Credential, nil
+}
+
+// CreateAuction starts a new auction and returns its ID, see NewAuction in the proto for the options
+func (c *Client) CreateAuction(ctx context.Context, req *proto.NewAuction) (int32, error) {
+	req.Lamport = c.tick()
+	var response *proto.AuctionCreated
+	err := c.callOnce(ctx, func(ctx context.Context, server proto.AuctionClient) (err error) {
+		response, err = server.CreateAuction(ctx, req)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	c.receive(response.Lamport)
+	return response.AuctionId, nil
+}
+
+// Auction gives the calls for one auction, ID 0 stands for every auction in Watch
+func (c *Client) Auction(id int32) *Auction {
+	return &Auction{client: c, id: id}
+}
+
+// the lamport time to send with a request
+func (c *Client) tick() int32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lamport++
+	return c.lamport
+}
+
+// merges the lamport time of an answer into the clock
+func (c *Client) receive(remote int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if remote > c.lamport {
+		c.lamport = remote
+	}
+	c.lamport++
+}
+
+// adds the credential that proves who is bidding to the metadata of the request
+func (c *Client) outgoing(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "credential", c.Credential())
+}
+
+// calls the server in use, and while it is down or not the leader the one it names as leader or else the next one
+// in the list. after every server has been tried it backs off before the next round, as there is probably an
+// election going on, until ctx ends or maxRounds is reached.
+// rpc gets a context that ends after attemptTimeout, so one server that hangs does not use up ctx.
+// only for requests that can be sent again, reads and the requests with a request id
+func (c *Client) call(ctx context.Context, rpc func(ctx context.Context, server proto.AuctionClient) error) error {
+	return c.try(ctx, false, rpc)
+}
+
+// like call, for requests a server would carry out again if they were retried. it only moves on to another server
+// while no server can have taken the request: when there is no connection to the server, or when the server
+// refused it for not being the leader. other failures are returned, as the request may still be carried out
+func (c *Client) callOnce(ctx context.Context, rpc func(ctx context.Context, server proto.AuctionClient) error) error {
+	return c.try(ctx, true, rpc)
+}
+
+func (c *Client) try(ctx context.Context, once bool, rpc func(ctx context.Context, server proto.AuctionClient) error) error {
+	var err error
+	for round := 0; round < maxRounds && ctx.Err() == nil; round++ {
+		if round > 0 && !backoff(ctx, round) {
+			break
+		}
+		for range c.servers {
+			c.mu.Lock()
+			index := c.current
+			c.mu.Unlock()
+
+			attempt, cancel := context.WithTimeout(ctx, attemptTimeout)
+			if once && !c.connect(attempt, index) {
+				err = status.Errorf(codes.Unavailable, "could not connect to %s", c.servers[index])
+			} else {
+				err = rpc(attempt, c.clients[index])
+				if once && ServerDown(err) && !refused(err) {
+					cancel()
+					return c.answered(err)
+				}
+			}
+			cancel()
+			if !ServerDown(err) || ctx.Err() != nil {
+				return c.answered(err)
+			}
+			c.failover(index, err)
+		}
+	}
+	return c.answered(err)
+}
+
+// merges the lamport time of a rejection into the clock
+func (c *Client) answered(err error) error {
+	if rejection := RejectionOf(err); rejection != nil {
+		c.receive(rejection.Lamport)
+	}
+	return err
+}
+
+// moves on to the leader named in the rejection of the server if it is one of the servers, otherwise to the next
+// server in the list. nothing changes if another request already moved on
+func (c *Client) failover(from int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current != from {
+		return
+	}
+	if rejection := RejectionOf(err); rejection != nil && rejection.LeaderAddress != "" {
+		for i, address := range c.servers {
+			if address == rejection.LeaderAddress && i != from {
+				c.current = i
+				return
+			}
+		}
+	}
+	c.current = (from + 1) % len(c.servers)
+}
+
+// waits until the connection to the server is up, false if it fails or ctx ends first
+func (c *Client) connect(ctx context.Context, index int) bool {
+	conn := c.conns[index]
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return true
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return false
+		case connectivity.Idle:
+			conn.Connect()
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+}
+
+// whether the server turned the request down for not being the leader before it did anything with it, rather than
+// failing to replicate it
+func refused(err error) bool {
+	return RejectionOf(err).GetReason() == proto.Reason_NOT_LEADER
+}
+
+// waits longer after every round, up to backoffCap, with full jitter so the clients of a cluster do not all come
+// back at once. false if ctx ends first
+func backoff(ctx context.Context, round int) bool {
+	limit := min(backoffCap, backoffBase<<(round-1))
+	timer := time.NewTimer(rand.N(limit) + 1)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// ServerDown reports whether the error means the server is gone or not the leader, as opposed to the request being
+// rejected. the client tries the other servers for these itself, but for Register and CreateAuction only when the
+// request can not have been carried out
+func ServerDown(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// RejectionOf returns the rejection in the status details of the error, nil if there is none
+func RejectionOf(err error) *proto.Rejection {
+	for _, detail := range status.Convert(err).Details() {
+		if rejection, ok := detail.(*proto.Rejection); ok {
+			return rejection
+		}
+	}
+	return nil
+}
+
+// a random id for a bid or acceptance, the servers answer a retry of it with the same id like the first attempt
+func newRequestId() string {
+	return crand.Text()
+}
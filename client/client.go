@@ -9,78 +9,116 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"AuctionServer/auctionclient"
 	proto "AuctionServer/grpc"
 )
 
-// represents a bidder in the auction, holding ID and the gRPC used to call RPC methods on the server
+// how long a command may take, long enough for the client to wait out a few elections
+const requestTimeout = 15 * time.Second
+
+// represents a bidder in the auction, the calls to the servers, the failover and the lamport clock are in auctionclient
 type Client struct {
-	ID           int32
-	Server       proto.AuctionClient
-	Backup       string
-	Lamport      int32
-	AmountOfBids int32
+	auctions *auctionclient.Client
+
+	mu      sync.Mutex                     // guards watches, as the watches run next to the commands
+	watches map[int32]*auctionclient.Watch // the watch of an auction, 0 is every auction
 }
 
 type Config struct {
-	ID      int32
-	Servers []string
+	ID         int32
+	Credential string
+	Name       string
+	Servers    []string
+	CA         string
+	Cert       string
+	Key        string
 }
 
 func parseConfig() Config {
-	id := flag.Int("id", 1, "bidder ID")
+	id := flag.Int("id", 0, "bidder ID from an earlier registration, 0 registers a new bidder")
+	credential := flag.String("credential", "", "credential issued with the bidder ID")
+	name := flag.String("name", "", "name to register a new bidder with")
 	servers := flag.String("servers", ":8081", "comma separated list of servers")
+	ca := flag.String("ca", "", "CA file the certificates of the servers are checked against, enables TLS")
+	cert := flag.String("cert", "", "client certificate file, for servers that require one")
+	key := flag.String("key", "", "private key file of the client certificate")
 	flag.Parse()
 
+	if (*cert == "") != (*key == "") {
+		log.Fatalf("-cert and -key must be given together")
+	}
+
 	var serverList []string
 	if *servers != "" {
 		serverList = strings.Split(*servers, ",")
 	}
 
 	return Config{
-		ID:      int32(*id),
-		Servers: serverList,
+		ID:         int32(*id),
+		Credential: *credential,
+		Name:       *name,
+		Servers:    serverList,
+		CA:         *ca,
+		Cert:       *cert,
+		Key:        *key,
 	}
 }
+
 func main() {
 	cfg := parseConfig()
 
-	//try first server
-	addr := cfg.Servers[0]
+	creds, err := transportCredentials(cfg)
+	if err != nil {
+		log.Fatalf("could not load the certificates: %v", err)
+	}
 
-	//Connecting to server
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	//the connections to the servers are made once they are used
+	auctions, err := auctionclient.NewWithConfig(auctionclient.Config{
+		Servers:     cfg.Servers,
+		Credentials: creds,
+		ID:          cfg.ID,
+		Credential:  cfg.Credential,
+	})
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
+	defer auctions.Close()
 	//creates a new instance of the object client
 	c := Client{
-		ID:           cfg.ID,
-		Server:       proto.NewAuctionClient(conn),
-		Backup:       cfg.Servers[1],
-		AmountOfBids: 0,
+		auctions: auctions,
+		watches:  make(map[int32]*auctionclient.Watch),
 	}
 
-	fmt.Printf("Connected to auction as client %d on server %s \n", c.ID, addr)
-	fmt.Println("Commands: bid <amount> | result | quit") //what the user can type into terminal
+	if auctions.ID() == 0 {
+		if err := c.Register(cfg.Name); err != nil {
+			log.Fatalf("could not register: %v", err)
+		}
+	}
+
+	fmt.Printf("Connected to auction as client %d on server %s \n", auctions.ID(), auctions.Server())
+	fmt.Println("Commands: create <seconds> [options] <item> | bid <auction> <amount> | max <auction> <amount> | accept <auction> | result <auction> | history <auction> [page] | watch <auction|all> | unwatch <auction|all> | quit") //what the user can type into terminal
 
 	//start listening for commands in terminal
 	c.listenCommands()
 }
 
-func (c *Client) incrementLamport() {
-	c.Lamport++
-}
-func (c *Client) updateLamportOnReceive(remote int32) {
-	if remote > c.Lamport {
-		c.Lamport = remote
+// Registers a new bidder, the servers only take bids with the ID and credential they issue
+func (c *Client) Register(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	id, credential, err := c.auctions.Register(ctx, name)
+	if err != nil {
+		return err
 	}
-	c.incrementLamport()
+	fmt.Printf("Registered as bidder %d, to bid as this bidder again start the client with -id=%d -credential=%s\n", id, id, credential)
+	return nil
 }
 
 // handles user input from terminal
@@ -102,119 +140,469 @@ func (c *Client) listenCommands() {
 		cmd := parts[0]
 
 		switch cmd {
+		case "create":
+			if len(parts) < 3 {
+				fmt.Println("needs duration and item, try again")
+				continue
+			}
+			durationInt, err := strconv.Atoi(parts[1])
+			if err != nil {
+				fmt.Println("duration must be an integer")
+				continue
+			}
+			req := &proto.NewAuction{Duration: int32(durationInt)}
+			//options come as key=value before the item, the rest of the line describes the item
+			rest, err := parseAuctionOptions(req, parts[2:])
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			if len(rest) == 0 {
+				fmt.Println("needs item, try again")
+				continue
+			}
+			req.Item = strings.Join(rest, " ")
+			if err := c.CreateAuction(req); err != nil {
+				fmt.Println("error in create", err)
+			}
+
 		case "bid":
-			if len(parts) != 2 {
-				fmt.Println("needs amount, try again")
+			if len(parts) != 3 {
+				fmt.Println("needs auction and amount, try again")
+				continue
+			}
+			//convert auction and bid to int
+			auctionInt, err := strconv.Atoi(parts[1])
+			if err != nil {
+				fmt.Println("auction must be an integer")
 				continue
 			}
-			//convert bid to int
-			amountInt, err := strconv.Atoi(parts[1])
+			amountInt, err := strconv.Atoi(parts[2])
 			if err != nil {
 				fmt.Println("amount must be an integer")
 				continue
 			}
 			//send bid to server
-			if err := c.Bid(int32(amountInt)); err != nil {
+			if err := c.Bid(int32(auctionInt), int32(amountInt)); err != nil {
 				fmt.Println("error in bid", err)
 			}
 
+		case "max":
+			if len(parts) != 3 {
+				fmt.Println("needs auction and maximum, try again")
+				continue
+			}
+			auctionInt, err := strconv.Atoi(parts[1])
+			if err != nil {
+				fmt.Println("auction must be an integer")
+				continue
+			}
+			maximumInt, err := strconv.Atoi(parts[2])
+			if err != nil {
+				fmt.Println("maximum must be an integer")
+				continue
+			}
+			//let the server bid up to the maximum
+			if err := c.PlaceMaxBid(int32(auctionInt), int32(maximumInt)); err != nil {
+				fmt.Println("error in max", err)
+			}
+
+		case "accept":
+			if len(parts) != 2 {
+				fmt.Println("needs auction, try again")
+				continue
+			}
+			auctionInt, err := strconv.Atoi(parts[1])
+			if err != nil {
+				fmt.Println("auction must be an integer")
+				continue
+			}
+			//accept the asking price of a dutch auction
+			if err := c.Accept(int32(auctionInt)); err != nil {
+				fmt.Println("error in accept", err)
+			}
+
 		case "result":
+			if len(parts) != 2 {
+				fmt.Println("needs auction, try again")
+				continue
+			}
+			auctionInt, err := strconv.Atoi(parts[1])
+			if err != nil {
+				fmt.Println("auction must be an integer")
+				continue
+			}
 			//get auction result
-			if err := c.Result(); err != nil {
+			if err := c.Result(int32(auctionInt)); err != nil {
 				fmt.Println("error in result", err)
 			}
+		case "history":
+			if len(parts) != 2 && len(parts) != 3 {
+				fmt.Println("needs auction and optionally page, try again")
+				continue
+			}
+			auctionInt, err := strconv.Atoi(parts[1])
+			if err != nil {
+				fmt.Println("auction must be an integer")
+				continue
+			}
+			pageInt := 1
+			if len(parts) == 3 {
+				pageInt, err = strconv.Atoi(parts[2])
+				if err != nil || pageInt < 1 {
+					fmt.Println("page must be a positive integer")
+					continue
+				}
+			}
+			//get every attempt to buy the item
+			if err := c.History(int32(auctionInt), int32(pageInt)); err != nil {
+				fmt.Println("error in history", err)
+			}
+
+		case "watch", "unwatch":
+			if len(parts) != 2 {
+				fmt.Println("needs auction or all, try again")
+				continue
+			}
+			//all is auction 0
+			auctionInt := 0
+			if parts[1] != "all" {
+				var err error
+				auctionInt, err = strconv.Atoi(parts[1])
+				if err != nil {
+					fmt.Println("auction must be an integer or all")
+					continue
+				}
+			}
+			if parts[0] == "unwatch" {
+				c.Unwatch(int32(auctionInt))
+				continue
+			}
+			//print the changes of the auction while still reading commands
+			if err := c.Watch(int32(auctionInt)); err != nil {
+				fmt.Println("error in watch", err)
+			}
 		case "quit":
 			fmt.Println("Quitting")
 			return
 		default:
-			fmt.Print("unknown command, valid commands: bid <amount> | result | quit")
+			fmt.Println("unknown command, valid commands: create <seconds> [options] <item> | bid <auction> <amount> | max <auction> <amount> | accept <auction> | result <auction> | history <auction> [page] | watch <auction|all> | unwatch <auction|all> | quit")
 		}
 	}
 }
 
-// Sends a bid(amount) RPC to the server
-func (c *Client) Bid(amount int32) error {
-	c.incrementLamport()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	c.AmountOfBids++
-
-	//proto message
-	req := &proto.Amount{
-		Id:           c.ID,           //bidder ID
-		Amount:       amount,         //bid amount
-		Lamport:      c.Lamport,      //lamport
-		AmountOfBids: c.AmountOfBids, //amount of bids
+// an increment is a fixed amount (10), a percentage (5%) or tiers of from:step (0:1,100:5,1000:25)
+func parseIncrement(value string) (*proto.IncrementRule, error) {
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		number, err := strconv.Atoi(percent)
+		if err != nil {
+			return nil, fmt.Errorf("increment percentage must be an integer")
+		}
+		return &proto.IncrementRule{Percent: int32(number)}, nil
+	}
+	if !strings.Contains(value, ":") {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("increment must be an integer")
+		}
+		return &proto.IncrementRule{Fixed: int32(number)}, nil
 	}
 
-	//meta data
-	md := metadata.Pairs("source", "client")
-	ctx = metadata.NewOutgoingContext(ctx, md)
+	rule := &proto.IncrementRule{}
+	for _, tier := range strings.Split(value, ",") {
+		from, step, _ := strings.Cut(tier, ":")
+		fromInt, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, fmt.Errorf("increment tiers must be integers, from:step")
+		}
+		stepInt, err := strconv.Atoi(step)
+		if err != nil {
+			return nil, fmt.Errorf("increment tiers must be integers, from:step")
+		}
+		rule.Tiers = append(rule.Tiers, &proto.IncrementTier{From: int32(fromInt), Step: int32(stepInt)})
+	}
+	return rule, nil
+}
 
-	//send rpc
-	response, err := c.Server.Bid(ctx, req)
-	if err != nil {
-		log.Printf("Server not responding")
-		c.LeaderNotResponding()
+// fills in the key=value options at the start of args and returns the arguments after them
+func parseAuctionOptions(req *proto.NewAuction, args []string) ([]string, error) {
+	for len(args) > 0 {
+		key, value, ok := strings.Cut(args[0], "=")
+		if !ok {
+			break
+		}
+		args = args[1:]
+		if key == "mode" {
+			req.Mode = value
+			continue
+		}
+		if key == "increment" {
+			increment, err := parseIncrement(value)
+			if err != nil {
+				return nil, err
+			}
+			req.Increment = increment
+			continue
+		}
 
-		log.Printf("Trying backup")
-		response, err = c.Server.Bid(ctx, req)
+		number, err := strconv.Atoi(value)
 		if err != nil {
-			log.Printf("No servers not responding: %v", err)
-			c.AmountOfBids--
-			return err
+			return nil, fmt.Errorf("option %s must be an integer", key)
+		}
+		switch key {
+		case "within":
+			req.ExtendWithin = int32(number)
+		case "extend":
+			req.ExtendBy = int32(number)
+		case "start":
+			req.StartPrice = int32(number)
+		case "step":
+			req.PriceStep = int32(number)
+		case "every":
+			req.StepEvery = int32(number)
+		case "floor":
+			req.FloorPrice = int32(number)
+		case "reserve":
+			req.ReservePrice = int32(number)
+		case "buynow":
+			req.BuyNowPrice = int32(number)
+		default:
+			return nil, fmt.Errorf("unknown option %s, valid options: within=<seconds> extend=<seconds> mode=<english|first-price|second-price|dutch> start=<price> step=<price> every=<seconds> floor=<price> reserve=<price> buynow=<price> increment=<amount|percent%%|from:step,...>", key)
 		}
+	}
+	return args, nil
+}
 
+// Starts a new auction of the item that stays open for req.Duration seconds
+func (c *Client) CreateAuction(req *proto.NewAuction) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	auctionId, err := c.auctions.CreateAuction(ctx, req)
+	if err != nil {
+		return err
 	}
-	//update local lamport from server reply
-	c.updateLamportOnReceive(response.Lamport)
-	fmt.Printf("Bid %d from client %d had outcome %s\n", amount, c.ID, response.GetOutcome())
+	fmt.Printf("Auction %d of %s was created and is open for %d seconds\n", auctionId, req.Item, req.Duration)
 	return nil
 }
 
-// get state of auction from server, get highest bid or result
-func (c *Client) Result() error {
-	c.incrementLamport()
+// Sends a bid(amount) RPC to the server
+func (c *Client) Bid(auctionId int32, amount int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	id := c.auctions.ID()
+	response, err := c.auctions.Auction(auctionId).Bid(ctx, amount)
+	if err != nil {
+		return c.rejected(fmt.Sprintf("Bid %d in auction %d from client %d", amount, auctionId, id), err)
+	}
+	endTime := time.UnixMilli(response.GetEndTime()).Format(time.TimeOnly)
+	if response.GetPrice() > 0 {
+		fmt.Printf("Bid %d in auction %d from client %d had outcome %s and bought the item at price %d\n", amount, auctionId, id, describe(response.GetOutcome()), response.GetPrice())
+		return nil
+	}
+	if response.GetOutbid() {
+		fmt.Printf("Bid %d in auction %d from client %d had outcome %s, but was outbid by a maximum bid, minimum next bid=%d, ends=%s\n", amount, auctionId, id, describe(response.GetOutcome()), response.GetMinBid(), endTime)
+		return nil
+	}
+	fmt.Printf("Bid %d in auction %d from client %d had outcome %s, ends=%s\n", amount, auctionId, id, describe(response.GetOutcome()), endTime)
+	return nil
+}
 
-	//meta data
-	md := metadata.Pairs("source", "client")
-	ctx := metadata.NewOutgoingContext(context.Background(), md)
+// Places a secret maximum, the server bids for the client up to it whenever someone else outbids them
+func (c *Client) PlaceMaxBid(auctionId int32, maximum int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
 
-	//send rpc
-	response, err := c.Server.Result(ctx, &proto.Empty{Lamport: c.Lamport})
+	id := c.auctions.ID()
+	response, err := c.auctions.Auction(auctionId).PlaceMaxBid(ctx, maximum)
 	if err != nil {
-		log.Printf("Server not responding")
-		c.LeaderNotResponding()
+		return c.rejected(fmt.Sprintf("Maximum bid %d in auction %d from client %d", maximum, auctionId, id), err)
+	}
+	endTime := time.UnixMilli(response.GetEndTime()).Format(time.TimeOnly)
+	switch {
+	case response.GetPrice() > 0:
+		fmt.Printf("Maximum bid %d in auction %d from client %d had outcome %s and bought the item at price %d\n", maximum, auctionId, id, describe(response.GetOutcome()), response.GetPrice())
+	case response.GetOutbid():
+		fmt.Printf("Maximum bid %d in auction %d from client %d had outcome %s, but is already outbid, minimum next bid=%d, ends=%s\n", maximum, auctionId, id, describe(response.GetOutcome()), response.GetMinBid(), endTime)
+	default:
+		fmt.Printf("Maximum bid %d in auction %d from client %d had outcome %s, ends=%s\n", maximum, auctionId, id, describe(response.GetOutcome()), endTime)
+	}
+	return nil
+}
 
-		log.Printf("Trying backup")
-		response, err = c.Server.Result(context.Background(), &proto.Empty{Lamport: c.Lamport})
-		if err != nil {
-			log.Printf("No servers not responding: %v", err)
-			c.AmountOfBids--
-			return err
-		}
+// Accepts the current asking price of a dutch auction
+func (c *Client) Accept(auctionId int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	id := c.auctions.ID()
+	response, err := c.auctions.Auction(auctionId).Accept(ctx)
+	if err != nil {
+		return c.rejected(fmt.Sprintf("Accepting auction %d from client %d", auctionId, id), err)
 	}
+	fmt.Printf("Auction %d was won by client %d at price %d\n", auctionId, id, response.GetPrice())
+	return nil
+}
 
-	c.updateLamportOnReceive(response.Lamport)
+// get state of auction from server, get highest bid or result
+func (c *Client) Result(auctionId int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	response, err := c.auctions.Auction(auctionId).Result(ctx)
+	if err != nil {
+		return err
+	}
 
 	status := "open"
 	if response.GetActionClosed() {
 		status = "closed"
 	}
-	fmt.Printf("Result of auction -> highestBid=%d, bidderId=%d, auction=%s \n", response.GetHighestBid(), response.GetId(), status)
+	endTime := time.UnixMilli(response.GetEndTime()).Format(time.TimeOnly)
+	sealed := response.GetMode() == "first-price" || response.GetMode() == "second-price"
+	switch {
+	case response.GetMode() == "dutch" && !response.GetActionClosed():
+		fmt.Printf("Result of auction %d (%s, %s) -> askingPrice=%d, ends=%s \n", response.GetAuctionId(), response.GetItem(), response.GetMode(), response.GetPrice(), endTime)
+	case response.GetReserveNotMet():
+		fmt.Printf("Result of auction %d (%s, %s) -> reserve not met, highestBid=%d, auction=%s \n", response.GetAuctionId(), response.GetItem(), response.GetMode(), response.GetHighestBid(), status)
+	case sealed && !response.GetActionClosed():
+		fmt.Printf("Result of auction %d (%s, %s) -> bids are sealed until the auction closes, ends=%s \n", response.GetAuctionId(), response.GetItem(), response.GetMode(), endTime)
+	case response.GetActionClosed():
+		fmt.Printf("Result of auction %d (%s, %s) -> winner=%d, highestBid=%d, price=%d, auction=%s \n", response.GetAuctionId(), response.GetItem(), response.GetMode(), response.GetId(), response.GetHighestBid(), response.GetPrice(), status)
+	case response.GetBuyNowPrice() > 0:
+		fmt.Printf("Result of auction %d (%s) -> highestBid=%d, bidderId=%d, minBid=%d, buyNow=%d, auction=%s, ends=%s \n", response.GetAuctionId(), response.GetItem(), response.GetHighestBid(), response.GetId(), response.GetMinBid(), response.GetBuyNowPrice(), status, endTime)
+	default:
+		fmt.Printf("Result of auction %d (%s) -> highestBid=%d, bidderId=%d, minBid=%d, auction=%s, ends=%s \n", response.GetAuctionId(), response.GetItem(), response.GetHighestBid(), response.GetId(), response.GetMinBid(), status, endTime)
+	}
 	return nil
 }
 
-func (c *Client) LeaderNotResponding() {
-	//Connecting to backup server
-	conn, err := grpc.NewClient(c.Backup, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// records per page of the history command
+const historyPageSize = 10
+
+// prints a page of the bids, maximum bids and acceptances of the auction, oldest first
+func (c *Client) History(auctionId int32, page int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	//any server can answer
+	response, err := c.auctions.Auction(auctionId).History(ctx, (page-1)*historyPageSize, historyPageSize)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		return err
 	}
 
-	//Update server
-	c.Server = proto.NewAuctionClient(conn)
+	pages := (response.GetTotal() + historyPageSize - 1) / historyPageSize
+	fmt.Printf("History of auction %d, page %d of %d:\n", auctionId, page, pages)
+	for _, record := range response.GetRecords() {
+		amount := strconv.Itoa(int(record.GetAmount()))
+		if record.GetAmount() == 0 && record.GetKind() != "accept" {
+			amount = "hidden"
+		}
+		at := time.UnixMilli(record.GetTime()).Format(time.TimeOnly)
+		outcome := describe(record.GetOutcome())
+		if record.GetReason() != proto.Reason_NO_REASON {
+			outcome += " (" + describe(record.GetReason()) + ")"
+		}
+		fmt.Printf("  time=%d at %s: %s by %d of %s had outcome %s\n", record.GetLamport(), at, record.GetKind(), record.GetBidder(), amount, outcome)
+	}
+	if response.GetNextOffset() > 0 {
+		fmt.Printf("more with: history %d %d\n", auctionId, page+1)
+	}
+	return nil
+}
+
+// Streams the changes of the auction (0 for every auction) and prints them as they come in, until Unwatch
+func (c *Client) Watch(auctionId int32) error {
+	c.mu.Lock()
+	_, ok := c.watches[auctionId]
+	c.mu.Unlock()
+	if ok {
+		return fmt.Errorf("already watching auction %d", auctionId)
+	}
+
+	//starting the watch can try every server in turn, so the watches that end meanwhile must not wait for it
+	w, err := c.auctions.Auction(auctionId).Watch(context.Background())
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	if _, ok := c.watches[auctionId]; ok {
+		c.mu.Unlock()
+		w.Stop()
+		return fmt.Errorf("already watching auction %d", auctionId)
+	}
+	c.watches[auctionId] = w
+	c.mu.Unlock()
+
+	go func() {
+		for update := range w.Updates {
+			printUpdate(update)
+		}
+		if err := w.Err(); err != nil {
+			fmt.Printf("\nWatch of auction %d ended: %v\n> ", auctionId, err)
+		}
+		c.mu.Lock()
+		if c.watches[auctionId] == w {
+			delete(c.watches, auctionId)
+		}
+		c.mu.Unlock()
+	}()
+	return nil
+}
+
+// Stops watching the auction
+func (c *Client) Unwatch(auctionId int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if w, ok := c.watches[auctionId]; ok {
+		w.Stop()
+		delete(c.watches, auctionId)
+	}
+}
+
+// prints an update in between the commands, the prompt is printed again after it
+func printUpdate(update *proto.Update) {
+	outcome := update.GetOutcome()
+	endTime := time.UnixMilli(outcome.GetEndTime()).Format(time.TimeOnly)
+	switch update.GetKind() {
+	case "created":
+		fmt.Printf("\n[auction %d] %s was created as %s auction, ends=%s (time=%d)\n> ", outcome.GetAuctionId(), outcome.GetItem(), outcome.GetMode(), endTime, update.GetLamport())
+	case "bid":
+		fmt.Printf("\n[auction %d] new highest bid %d by %d, minBid=%d (time=%d)\n> ", outcome.GetAuctionId(), outcome.GetHighestBid(), outcome.GetId(), outcome.GetMinBid(), update.GetLamport())
+	case "extended":
+		fmt.Printf("\n[auction %d] extended, ends=%s (time=%d)\n> ", outcome.GetAuctionId(), endTime, update.GetLamport())
+	case "price":
+		fmt.Printf("\n[auction %d] asking price dropped to %d (time=%d)\n> ", outcome.GetAuctionId(), outcome.GetPrice(), update.GetLamport())
+	case "closed":
+		if outcome.GetReserveNotMet() {
+			fmt.Printf("\n[auction %d] closed, reserve not met (time=%d)\n> ", outcome.GetAuctionId(), update.GetLamport())
+		} else if outcome.GetId() == 0 {
+			fmt.Printf("\n[auction %d] closed without a sale (time=%d)\n> ", outcome.GetAuctionId(), update.GetLamport())
+		} else {
+			fmt.Printf("\n[auction %d] closed, winner=%d, price=%d (time=%d)\n> ", outcome.GetAuctionId(), outcome.GetId(), outcome.GetPrice(), update.GetLamport())
+		}
+	}
+}
+
+// prints why the server rejected the request, errors that are not rejections are returned
+func (c *Client) rejected(what string, err error) error {
+	rejection := auctionclient.RejectionOf(err)
+	if rejection == nil || status.Code(err) != codes.FailedPrecondition {
+		return err
+	}
+	outcome := fmt.Sprintf("%s had outcome %s (%s)", what, describe(rejection.Outcome), describe(rejection.Reason))
+	if rejection.MinBid > 0 {
+		outcome += fmt.Sprintf(", minimum next bid=%d", rejection.MinBid)
+	}
+	if rejection.EndTime > 0 {
+		outcome += ", ends=" + time.UnixMilli(rejection.EndTime).Format(time.TimeOnly)
+	}
+	fmt.Println(outcome)
+	return nil
+}
 
-	log.Printf("Server updated to %v", c.Backup)
+// turns an outcome or reason into words, e.g. increment too small
+func describe(value fmt.Stringer) string {
+	return strings.ToLower(strings.ReplaceAll(value.String(), "_", " "))
 }
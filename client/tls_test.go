@@ -0,0 +1,119 @@
+package main
+
+import (
+	"AuctionServer/internal/testpki"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+// serves a health service over TLS with a certificate from the cluster CA, which asks for a certificate from the
+// bidder CA. returns the address and a function saying whether the caller of the last call showed one
+func serveTLS(t *testing.T, clusterCA, bidderCA *testpki.CA) (string, func() bool) {
+	t.Helper()
+	certFile, keyFile := clusterCA.Issue(t, "server")
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(bidderCA.CertFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(data)
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, ClientCAs: clientCAs, ClientAuth: tls.VerifyClientCertIfGiven}
+
+	var mu sync.Mutex
+	var shown bool
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)), grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		p, _ := peer.FromContext(ctx)
+		info, _ := p.AuthInfo.(credentials.TLSInfo)
+		mu.Lock()
+		shown = len(info.State.PeerCertificates) > 0
+		mu.Unlock()
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return "localhost:" + port, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return shown
+	}
+}
+
+// makes one call with the credentials of cfg
+func call(t *testing.T, address string, cfg Config) error {
+	t.Helper()
+	creds, err := transportCredentials(cfg)
+	if err != nil {
+		t.Fatalf("credentials: %v", err)
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestTransportCredentials(t *testing.T) {
+	dir := t.TempDir()
+	clusterCA := testpki.NewCA(t, dir, "cluster")
+	bidderCA := testpki.NewCA(t, dir, "bidders")
+	certFile, keyFile := bidderCA.Issue(t, "alice")
+	address, shown := serveTLS(t, clusterCA, bidderCA)
+
+	if call(t, address, Config{}) == nil {
+		t.Error("a client without -ca reached a TLS server in plain text")
+	}
+	if call(t, address, Config{Cert: certFile, Key: keyFile}) == nil {
+		t.Error("the server was trusted without -ca, though its CA is not a system CA")
+	}
+
+	if err := call(t, address, Config{CA: clusterCA.CertFile}); err != nil {
+		t.Fatalf("call with -ca: %v", err)
+	}
+	if shown() {
+		t.Error("a certificate was shown without -cert")
+	}
+
+	if err := call(t, address, Config{CA: clusterCA.CertFile, Cert: certFile, Key: keyFile}); err != nil {
+		t.Fatalf("call with -ca and -cert: %v", err)
+	}
+	if !shown() {
+		t.Error("the certificate of -cert was not shown")
+	}
+}
+
+func TestTransportCredentialsWithoutCertificates(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transportCredentials(Config{CA: empty}); err == nil {
+		t.Error("a CA file without certificates was accepted")
+	}
+}
@@ -21,12 +21,144 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// how a bid, maximum bid or acceptance ended
+type BidOutcome int32
+
+const (
+	BidOutcome_OUTCOME_UNSPECIFIED BidOutcome = 0
+	BidOutcome_SUCCESS             BidOutcome = 1
+	BidOutcome_FAIL                BidOutcome = 2 //the request was not valid, the reason says why
+	BidOutcome_EXCEPTION           BidOutcome = 3 //the auction was not open
+)
+
+// Enum value maps for BidOutcome.
+var (
+	BidOutcome_name = map[int32]string{
+		0: "OUTCOME_UNSPECIFIED",
+		1: "SUCCESS",
+		2: "FAIL",
+		3: "EXCEPTION",
+	}
+	BidOutcome_value = map[string]int32{
+		"OUTCOME_UNSPECIFIED": 0,
+		"SUCCESS":             1,
+		"FAIL":                2,
+		"EXCEPTION":           3,
+	}
+)
+
+func (x BidOutcome) Enum() *BidOutcome {
+	p := new(BidOutcome)
+	*p = x
+	return p
+}
+
+func (x BidOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BidOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_proto_enumTypes[0].Descriptor()
+}
+
+func (BidOutcome) Type() protoreflect.EnumType {
+	return &file_proto_proto_enumTypes[0]
+}
+
+func (x BidOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BidOutcome.Descriptor instead.
+func (BidOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{0}
+}
+
+// why a request was rejected
+type Reason int32
+
+const (
+	Reason_NO_REASON           Reason = 0
+	Reason_BELOW_CURRENT       Reason = 1 //not above the highest bid, or not positive in a sealed-bid auction
+	Reason_AUCTION_CLOSED      Reason = 2
+	Reason_NOT_REGISTERED      Reason = 3
+	Reason_INCREMENT_TOO_SMALL Reason = 4 //above the highest bid, but by less than the increment
+	Reason_NOT_LEADER          Reason = 5 //only the leader takes bids, try another server
+	Reason_NOT_STARTED         Reason = 6
+	Reason_ALREADY_BID         Reason = 7 //sealed-bid auctions take one bid per bidder
+	Reason_WRONG_MODE          Reason = 8 //e.g. a bid in a dutch auction or an acceptance in an english one
+	Reason_NO_SUCH_AUCTION     Reason = 9
+	Reason_WRONG_CREDENTIAL    Reason = 10 //the bidder is registered, but the credential is not theirs
+	Reason_NOT_COMMITTED       Reason = 11 //the leader could not replicate the request in time, it may still be carried out
+)
+
+// Enum value maps for Reason.
+var (
+	Reason_name = map[int32]string{
+		0:  "NO_REASON",
+		1:  "BELOW_CURRENT",
+		2:  "AUCTION_CLOSED",
+		3:  "NOT_REGISTERED",
+		4:  "INCREMENT_TOO_SMALL",
+		5:  "NOT_LEADER",
+		6:  "NOT_STARTED",
+		7:  "ALREADY_BID",
+		8:  "WRONG_MODE",
+		9:  "NO_SUCH_AUCTION",
+		10: "WRONG_CREDENTIAL",
+		11: "NOT_COMMITTED",
+	}
+	Reason_value = map[string]int32{
+		"NO_REASON":           0,
+		"BELOW_CURRENT":       1,
+		"AUCTION_CLOSED":      2,
+		"NOT_REGISTERED":      3,
+		"INCREMENT_TOO_SMALL": 4,
+		"NOT_LEADER":          5,
+		"NOT_STARTED":         6,
+		"ALREADY_BID":         7,
+		"WRONG_MODE":          8,
+		"NO_SUCH_AUCTION":     9,
+		"WRONG_CREDENTIAL":    10,
+		"NOT_COMMITTED":       11,
+	}
+)
+
+func (x Reason) Enum() *Reason {
+	p := new(Reason)
+	*p = x
+	return p
+}
+
+func (x Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_proto_enumTypes[1].Descriptor()
+}
+
+func (Reason) Type() protoreflect.EnumType {
+	return &file_proto_proto_enumTypes[1]
+}
+
+func (x Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Reason.Descriptor instead.
+func (Reason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{1}
+}
+
 type Amount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Lamport       int32                  `protobuf:"varint,2,opt,name=lamport,proto3" json:"lamport,omitempty"`
 	Amount        int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	AmountOfBids  int32                  `protobuf:"varint,4,opt,name=amountOfBids,proto3" json:"amountOfBids,omitempty"`
+	AuctionId     int32                  `protobuf:"varint,5,opt,name=auctionId,proto3" json:"auctionId,omitempty"`
+	RequestId     string                 `protobuf:"bytes,6,opt,name=requestId,proto3" json:"requestId,omitempty"` //chosen by the client for each bid, a retry with the same id gets the answer to the first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,17 +221,122 @@ func (x *Amount) GetAmountOfBids() int32 {
 	return 0
 }
 
+func (x *Amount) GetAuctionId() int32 {
+	if x != nil {
+		return x.AuctionId
+	}
+	return 0
+}
+
+func (x *Amount) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// sent in the status details of the error when a request is rejected
+type Rejection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outcome       BidOutcome             `protobuf:"varint,1,opt,name=outcome,proto3,enum=BidOutcome" json:"outcome,omitempty"`
+	Reason        Reason                 `protobuf:"varint,2,opt,name=reason,proto3,enum=Reason" json:"reason,omitempty"`
+	MinBid        int32                  `protobuf:"varint,3,opt,name=minBid,proto3" json:"minBid,omitempty"` //the lowest valid bid of an open english auction
+	EndTime       int64                  `protobuf:"varint,4,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Lamport       int32                  `protobuf:"varint,5,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	LeaderAddress string                 `protobuf:"bytes,6,opt,name=leaderAddress,proto3" json:"leaderAddress,omitempty"` //with NOT_LEADER or NOT_COMMITTED, the leader as far as the server knows
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rejection) Reset() {
+	*x = Rejection{}
+	mi := &file_proto_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rejection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rejection) ProtoMessage() {}
+
+func (x *Rejection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rejection.ProtoReflect.Descriptor instead.
+func (*Rejection) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Rejection) GetOutcome() BidOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return BidOutcome_OUTCOME_UNSPECIFIED
+}
+
+func (x *Rejection) GetReason() Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return Reason_NO_REASON
+}
+
+func (x *Rejection) GetMinBid() int32 {
+	if x != nil {
+		return x.MinBid
+	}
+	return 0
+}
+
+func (x *Rejection) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *Rejection) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+func (x *Rejection) GetLeaderAddress() string {
+	if x != nil {
+		return x.LeaderAddress
+	}
+	return ""
+}
+
+// the answer to a successful request, rejected requests get an error with a Rejection instead
 type Ack struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Outcome       string                 `protobuf:"bytes,1,opt,name=outcome,proto3" json:"outcome,omitempty"` //fail, success or exception
+	Outcome       BidOutcome             `protobuf:"varint,1,opt,name=outcome,proto3,enum=BidOutcome" json:"outcome,omitempty"`
 	Lamport       int32                  `protobuf:"varint,2,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	EndTime       int64                  `protobuf:"varint,3,opt,name=endTime,proto3" json:"endTime,omitempty"`           //unix milliseconds, moves when a bid extends the auction
+	Price         int32                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`               //what was paid when the bid or acceptance won the auction outright
+	MinBid        int32                  `protobuf:"varint,5,opt,name=minBid,proto3" json:"minBid,omitempty"`             //the lowest amount a rejected or outbid bid could have had in an open english auction
+	Outbid        bool                   `protobuf:"varint,6,opt,name=outbid,proto3" json:"outbid,omitempty"`             //the bid was accepted, but a maximum bid of someone else raised their bid above it
+	Reason        Reason                 `protobuf:"varint,7,opt,name=reason,proto3,enum=Reason" json:"reason,omitempty"` //only used within the server, as rejected requests are answered with an error
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Ack) Reset() {
 	*x = Ack{}
-	mi := &file_proto_proto_msgTypes[1]
+	mi := &file_proto_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -111,7 +348,7 @@ func (x *Ack) String() string {
 func (*Ack) ProtoMessage() {}
 
 func (x *Ack) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_proto_msgTypes[1]
+	mi := &file_proto_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -124,14 +361,14 @@ func (x *Ack) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ack.ProtoReflect.Descriptor instead.
 func (*Ack) Descriptor() ([]byte, []int) {
-	return file_proto_proto_rawDescGZIP(), []int{1}
+	return file_proto_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Ack) GetOutcome() string {
+func (x *Ack) GetOutcome() BidOutcome {
 	if x != nil {
 		return x.Outcome
 	}
-	return ""
+	return BidOutcome_OUTCOME_UNSPECIFIED
 }
 
 func (x *Ack) GetLamport() int32 {
@@ -141,16 +378,52 @@ func (x *Ack) GetLamport() int32 {
 	return 0
 }
 
+func (x *Ack) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *Ack) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Ack) GetMinBid() int32 {
+	if x != nil {
+		return x.MinBid
+	}
+	return 0
+}
+
+func (x *Ack) GetOutbid() bool {
+	if x != nil {
+		return x.Outbid
+	}
+	return false
+}
+
+func (x *Ack) GetReason() Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return Reason_NO_REASON
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Lamport       int32                  `protobuf:"varint,1,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	AuctionId     int32                  `protobuf:"varint,2,opt,name=auctionId,proto3" json:"auctionId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_proto_proto_msgTypes[2]
+	mi := &file_proto_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -162,7 +435,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_proto_msgTypes[2]
+	mi := &file_proto_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,7 +448,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_proto_proto_rawDescGZIP(), []int{2}
+	return file_proto_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Empty) GetLamport() int32 {
@@ -185,19 +458,35 @@ func (x *Empty) GetLamport() int32 {
 	return 0
 }
 
+func (x *Empty) GetAuctionId() int32 {
+	if x != nil {
+		return x.AuctionId
+	}
+	return 0
+}
+
 type Outcome struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	HighestBid    int32                  `protobuf:"varint,2,opt,name=highestBid,proto3" json:"highestBid,omitempty"`
 	ActionClosed  bool                   `protobuf:"varint,3,opt,name=actionClosed,proto3" json:"actionClosed,omitempty"`
 	Lamport       int32                  `protobuf:"varint,4,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	AuctionId     int32                  `protobuf:"varint,5,opt,name=auctionId,proto3" json:"auctionId,omitempty"`
+	Item          string                 `protobuf:"bytes,6,opt,name=item,proto3" json:"item,omitempty"`
+	StartTime     int64                  `protobuf:"varint,7,opt,name=startTime,proto3" json:"startTime,omitempty"` //unix milliseconds
+	EndTime       int64                  `protobuf:"varint,8,opt,name=endTime,proto3" json:"endTime,omitempty"`     //unix milliseconds
+	Mode          string                 `protobuf:"bytes,9,opt,name=mode,proto3" json:"mode,omitempty"`
+	Price         int32                  `protobuf:"varint,10,opt,name=price,proto3" json:"price,omitempty"`                 //what the winner pays once the auction is closed, the current asking price of an open dutch auction
+	ReserveNotMet bool                   `protobuf:"varint,11,opt,name=reserveNotMet,proto3" json:"reserveNotMet,omitempty"` //the auction closed with a highest bid below the hidden reserve, so nothing was sold
+	BuyNowPrice   int32                  `protobuf:"varint,12,opt,name=buyNowPrice,proto3" json:"buyNowPrice,omitempty"`     //0 if the auction has no buy-it-now price
+	MinBid        int32                  `protobuf:"varint,13,opt,name=minBid,proto3" json:"minBid,omitempty"`               //the lowest valid next bid of an open english auction
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Outcome) Reset() {
 	*x = Outcome{}
-	mi := &file_proto_proto_msgTypes[3]
+	mi := &file_proto_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -209,7 +498,7 @@ func (x *Outcome) String() string {
 func (*Outcome) ProtoMessage() {}
 
 func (x *Outcome) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_proto_msgTypes[3]
+	mi := &file_proto_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,7 +511,7 @@ func (x *Outcome) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Outcome.ProtoReflect.Descriptor instead.
 func (*Outcome) Descriptor() ([]byte, []int) {
-	return file_proto_proto_rawDescGZIP(), []int{3}
+	return file_proto_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Outcome) GetId() int32 {
@@ -253,80 +542,2731 @@ func (x *Outcome) GetLamport() int32 {
 	return 0
 }
 
-var File_proto_proto protoreflect.FileDescriptor
+func (x *Outcome) GetAuctionId() int32 {
+	if x != nil {
+		return x.AuctionId
+	}
+	return 0
+}
 
-const file_proto_proto_rawDesc = "" +
-	"\n" +
-	"\vproto.proto\"n\n" +
-	"\x06Amount\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
-	"\alamport\x18\x02 \x01(\x05R\alamport\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x05R\x06amount\x12\"\n" +
-	"\famountOfBids\x18\x04 \x01(\x05R\famountOfBids\"9\n" +
-	"\x03Ack\x12\x18\n" +
-	"\aoutcome\x18\x01 \x01(\tR\aoutcome\x12\x18\n" +
-	"\alamport\x18\x02 \x01(\x05R\alamport\"!\n" +
-	"\x05Empty\x12\x18\n" +
-	"\alamport\x18\x01 \x01(\x05R\alamport\"w\n" +
-	"\aOutcome\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1e\n" +
-	"\n" +
-	"highestBid\x18\x02 \x01(\x05R\n" +
-	"highestBid\x12\"\n" +
-	"\factionClosed\x18\x03 \x01(\bR\factionClosed\x12\x18\n" +
-	"\alamport\x18\x04 \x01(\x05R\alamport2;\n" +
-	"\aAuction\x12\x14\n" +
-	"\x03Bid\x12\a.Amount\x1a\x04.Ack\x12\x1a\n" +
-	"\x06Result\x12\x06.Empty\x1a\b.OutcomeB\x10Z\x0eHW5/grpc/protob\x06proto3"
+func (x *Outcome) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
 
-var (
-	file_proto_proto_rawDescOnce sync.Once
-	file_proto_proto_rawDescData []byte
-)
+func (x *Outcome) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
 
-func file_proto_proto_rawDescGZIP() []byte {
-	file_proto_proto_rawDescOnce.Do(func() {
-		file_proto_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_proto_rawDesc), len(file_proto_proto_rawDesc)))
-	})
-	return file_proto_proto_rawDescData
+func (x *Outcome) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
 }
 
-var file_proto_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_proto_proto_goTypes = []any{
-	(*Amount)(nil),  // 0: Amount
-	(*Ack)(nil),     // 1: Ack
-	(*Empty)(nil),   // 2: Empty
-	(*Outcome)(nil), // 3: Outcome
+func (x *Outcome) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
 }
-var file_proto_proto_depIdxs = []int32{
-	0, // 0: Auction.Bid:input_type -> Amount
-	2, // 1: Auction.Result:input_type -> Empty
-	1, // 2: Auction.Bid:output_type -> Ack
-	3, // 3: Auction.Result:output_type -> Outcome
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *Outcome) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
 }
 
-func init() { file_proto_proto_init() }
-func file_proto_proto_init() {
-	if File_proto_proto != nil {
-		return
+func (x *Outcome) GetReserveNotMet() bool {
+	if x != nil {
+		return x.ReserveNotMet
+	}
+	return false
+}
+
+func (x *Outcome) GetBuyNowPrice() int32 {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return 0
+}
+
+func (x *Outcome) GetMinBid() int32 {
+	if x != nil {
+		return x.MinBid
+	}
+	return 0
+}
+
+type HistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     int32                  `protobuf:"varint,1,opt,name=auctionId,proto3" json:"auctionId,omitempty"`
+	Lamport       int32                  `protobuf:"varint,2,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"` //records to skip, the nextOffset of the previous page
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`   //records per page, 20 if not set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_proto_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HistoryRequest) GetAuctionId() int32 {
+	if x != nil {
+		return x.AuctionId
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type HistoryPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*BidRecord           `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`        //oldest first
+	NextOffset    int32                  `protobuf:"varint,2,opt,name=nextOffset,proto3" json:"nextOffset,omitempty"` //0 if this is the last page
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Lamport       int32                  `protobuf:"varint,4,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryPage) Reset() {
+	*x = HistoryPage{}
+	mi := &file_proto_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryPage) ProtoMessage() {}
+
+func (x *HistoryPage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryPage.ProtoReflect.Descriptor instead.
+func (*HistoryPage) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HistoryPage) GetRecords() []*BidRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *HistoryPage) GetNextOffset() int32 {
+	if x != nil {
+		return x.NextOffset
+	}
+	return 0
+}
+
+func (x *HistoryPage) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *HistoryPage) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+// pushed to watching clients whenever an auction changes
+type Update struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` //created, bid, extended, price or closed
+	Lamport       int32                  `protobuf:"varint,2,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	Outcome       *Outcome               `protobuf:"bytes,3,opt,name=outcome,proto3" json:"outcome,omitempty"` //the auction after the change, sealed bids stay hidden
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Update) Reset() {
+	*x = Update{}
+	mi := &file_proto_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Update) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Update) ProtoMessage() {}
+
+func (x *Update) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Update.ProtoReflect.Descriptor instead.
+func (*Update) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Update) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Update) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+func (x *Update) GetOutcome() *Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+type NewAuction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`          //description of what is sold
+	Duration      int32                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"` //seconds the auction stays open, only used when endTime is not set
+	Lamport       int32                  `protobuf:"varint,3,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	StartTime     int64                  `protobuf:"varint,4,opt,name=startTime,proto3" json:"startTime,omitempty"`        //unix milliseconds, the leader uses the current time when not set
+	EndTime       int64                  `protobuf:"varint,5,opt,name=endTime,proto3" json:"endTime,omitempty"`            //unix milliseconds
+	ExtendWithin  int32                  `protobuf:"varint,6,opt,name=extendWithin,proto3" json:"extendWithin,omitempty"`  //seconds before the end in which a successful bid extends the auction, 0 turns it off
+	ExtendBy      int32                  `protobuf:"varint,7,opt,name=extendBy,proto3" json:"extendBy,omitempty"`          //seconds such a bid extends the end time with
+	Mode          string                 `protobuf:"bytes,8,opt,name=mode,proto3" json:"mode,omitempty"`                   //english (default), first-price, second-price or dutch, first-price and second-price are sealed-bid
+	StartPrice    int32                  `protobuf:"varint,9,opt,name=startPrice,proto3" json:"startPrice,omitempty"`      //dutch only, the asking price it starts at
+	PriceStep     int32                  `protobuf:"varint,10,opt,name=priceStep,proto3" json:"priceStep,omitempty"`       //dutch only, how much the asking price drops every step
+	StepEvery     int32                  `protobuf:"varint,11,opt,name=stepEvery,proto3" json:"stepEvery,omitempty"`       //dutch only, seconds between the drops
+	FloorPrice    int32                  `protobuf:"varint,12,opt,name=floorPrice,proto3" json:"floorPrice,omitempty"`     //dutch only, the asking price never drops below it
+	ReservePrice  int32                  `protobuf:"varint,13,opt,name=reservePrice,proto3" json:"reservePrice,omitempty"` //hidden, nothing is sold if the highest bid is below it
+	BuyNowPrice   int32                  `protobuf:"varint,14,opt,name=buyNowPrice,proto3" json:"buyNowPrice,omitempty"`   //a bid of at least this amount wins at this price and closes the auction at once
+	Increment     *IncrementRule         `protobuf:"bytes,15,opt,name=increment,proto3" json:"increment,omitempty"`        //english only, how much a bid must be above the highest, at least 1 if not set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewAuction) Reset() {
+	*x = NewAuction{}
+	mi := &file_proto_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewAuction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewAuction) ProtoMessage() {}
+
+func (x *NewAuction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewAuction.ProtoReflect.Descriptor instead.
+func (*NewAuction) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NewAuction) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *NewAuction) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *NewAuction) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+func (x *NewAuction) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *NewAuction) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *NewAuction) GetExtendWithin() int32 {
+	if x != nil {
+		return x.ExtendWithin
+	}
+	return 0
+}
+
+func (x *NewAuction) GetExtendBy() int32 {
+	if x != nil {
+		return x.ExtendBy
+	}
+	return 0
+}
+
+func (x *NewAuction) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *NewAuction) GetStartPrice() int32 {
+	if x != nil {
+		return x.StartPrice
+	}
+	return 0
+}
+
+func (x *NewAuction) GetPriceStep() int32 {
+	if x != nil {
+		return x.PriceStep
+	}
+	return 0
+}
+
+func (x *NewAuction) GetStepEvery() int32 {
+	if x != nil {
+		return x.StepEvery
+	}
+	return 0
+}
+
+func (x *NewAuction) GetFloorPrice() int32 {
+	if x != nil {
+		return x.FloorPrice
+	}
+	return 0
+}
+
+func (x *NewAuction) GetReservePrice() int32 {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return 0
+}
+
+func (x *NewAuction) GetBuyNowPrice() int32 {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return 0
+}
+
+func (x *NewAuction) GetIncrement() *IncrementRule {
+	if x != nil {
+		return x.Increment
+	}
+	return nil
+}
+
+// only one of fixed, percent and tiers is set
+type IncrementRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fixed         int32                  `protobuf:"varint,1,opt,name=fixed,proto3" json:"fixed,omitempty"`     //every bid must be at least this much above the highest bid
+	Percent       int32                  `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"` //every bid must be at least this percentage above the highest bid, rounded up
+	Tiers         []*IncrementTier       `protobuf:"bytes,3,rep,name=tiers,proto3" json:"tiers,omitempty"`      //sorted by from, the tier with the largest from at or below the highest bid applies
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementRule) Reset() {
+	*x = IncrementRule{}
+	mi := &file_proto_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementRule) ProtoMessage() {}
+
+func (x *IncrementRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementRule.ProtoReflect.Descriptor instead.
+func (*IncrementRule) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IncrementRule) GetFixed() int32 {
+	if x != nil {
+		return x.Fixed
+	}
+	return 0
+}
+
+func (x *IncrementRule) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *IncrementRule) GetTiers() []*IncrementTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type IncrementTier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int32                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	Step          int32                  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementTier) Reset() {
+	*x = IncrementTier{}
+	mi := &file_proto_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementTier) ProtoMessage() {}
+
+func (x *IncrementTier) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementTier.ProtoReflect.Descriptor instead.
+func (*IncrementTier) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *IncrementTier) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *IncrementTier) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+// accepts the current asking price of a dutch auction
+type Acceptance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Lamport       int32                  `protobuf:"varint,2,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	AuctionId     int32                  `protobuf:"varint,3,opt,name=auctionId,proto3" json:"auctionId,omitempty"`
+	RequestId     string                 `protobuf:"bytes,4,opt,name=requestId,proto3" json:"requestId,omitempty"` //like the one of a bid, so a retried acceptance can not buy the item twice or lose it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Acceptance) Reset() {
+	*x = Acceptance{}
+	mi := &file_proto_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Acceptance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Acceptance) ProtoMessage() {}
+
+func (x *Acceptance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Acceptance.ProtoReflect.Descriptor instead.
+func (*Acceptance) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Acceptance) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Acceptance) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+func (x *Acceptance) GetAuctionId() int32 {
+	if x != nil {
+		return x.AuctionId
+	}
+	return 0
+}
+
+func (x *Acceptance) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type AuctionCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     int32                  `protobuf:"varint,1,opt,name=auctionId,proto3" json:"auctionId,omitempty"`
+	Lamport       int32                  `protobuf:"varint,2,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionCreated) Reset() {
+	*x = AuctionCreated{}
+	mi := &file_proto_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionCreated) ProtoMessage() {}
+
+func (x *AuctionCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionCreated.ProtoReflect.Descriptor instead.
+func (*AuctionCreated) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AuctionCreated) GetAuctionId() int32 {
+	if x != nil {
+		return x.AuctionId
+	}
+	return 0
+}
+
+func (x *AuctionCreated) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+// a command in the replicated log, an empty command is a no-op appended by a new leader
+type Command struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Command_Bid
+	//	*Command_Create
+	//	*Command_Close
+	//	*Command_Drop
+	//	*Command_Accept
+	//	*Command_MaxBid
+	//	*Command_Register
+	Kind          isCommand_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_proto_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command.ProtoReflect.Descriptor instead.
+func (*Command) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Command) GetKind() isCommand_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *Command) GetBid() *Amount {
+	if x != nil {
+		if x, ok := x.Kind.(*Command_Bid); ok {
+			return x.Bid
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetCreate() *NewAuction {
+	if x != nil {
+		if x, ok := x.Kind.(*Command_Create); ok {
+			return x.Create
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetClose() *CloseAuction {
+	if x != nil {
+		if x, ok := x.Kind.(*Command_Close); ok {
+			return x.Close
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetDrop() *PriceDrop {
+	if x != nil {
+		if x, ok := x.Kind.(*Command_Drop); ok {
+			return x.Drop
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetAccept() *Acceptance {
+	if x != nil {
+		if x, ok := x.Kind.(*Command_Accept); ok {
+			return x.Accept
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetMaxBid() *Amount {
+	if x != nil {
+		if x, ok := x.Kind.(*Command_MaxBid); ok {
+			return x.MaxBid
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetRegister() *NewBidder {
+	if x != nil {
+		if x, ok := x.Kind.(*Command_Register); ok {
+			return x.Register
+		}
+	}
+	return nil
+}
+
+type isCommand_Kind interface {
+	isCommand_Kind()
+}
+
+type Command_Bid struct {
+	Bid *Amount `protobuf:"bytes,1,opt,name=bid,proto3,oneof"`
+}
+
+type Command_Create struct {
+	Create *NewAuction `protobuf:"bytes,2,opt,name=create,proto3,oneof"`
+}
+
+type Command_Close struct {
+	Close *CloseAuction `protobuf:"bytes,3,opt,name=close,proto3,oneof"`
+}
+
+type Command_Drop struct {
+	Drop *PriceDrop `protobuf:"bytes,4,opt,name=drop,proto3,oneof"`
+}
+
+type Command_Accept struct {
+	Accept *Acceptance `protobuf:"bytes,5,opt,name=accept,proto3,oneof"`
+}
+
+type Command_MaxBid struct {
+	MaxBid *Amount `protobuf:"bytes,6,opt,name=maxBid,proto3,oneof"`
+}
+
+type Command_Register struct {
+	Register *NewBidder `protobuf:"bytes,7,opt,name=register,proto3,oneof"`
+}
+
+func (*Command_Bid) isCommand_Kind() {}
+
+func (*Command_Create) isCommand_Kind() {}
+
+func (*Command_Close) isCommand_Kind() {}
+
+func (*Command_Drop) isCommand_Kind() {}
+
+func (*Command_Accept) isCommand_Kind() {}
+
+func (*Command_MaxBid) isCommand_Kind() {}
+
+func (*Command_Register) isCommand_Kind() {}
+
+type Registration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Lamport       int32                  `protobuf:"varint,2,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Registration) Reset() {
+	*x = Registration{}
+	mi := &file_proto_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Registration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Registration) ProtoMessage() {}
+
+func (x *Registration) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Registration.ProtoReflect.Descriptor instead.
+func (*Registration) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Registration) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Registration) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+// the credential has to be sent as the credential metadata with every bid, maximum bid and acceptance of the bidder
+type Registered struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Credential    string                 `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"`
+	Lamport       int32                  `protobuf:"varint,3,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Registered) Reset() {
+	*x = Registered{}
+	mi := &file_proto_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Registered) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Registered) ProtoMessage() {}
+
+func (x *Registered) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Registered.ProtoReflect.Descriptor instead.
+func (*Registered) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Registered) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Registered) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+func (x *Registered) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+// only the hash of the credential is replicated, so the log and snapshots can not be used to impersonate a bidder
+type NewBidder struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CredentialHash []byte                 `protobuf:"bytes,2,opt,name=credentialHash,proto3" json:"credentialHash,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NewBidder) Reset() {
+	*x = NewBidder{}
+	mi := &file_proto_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewBidder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewBidder) ProtoMessage() {}
+
+func (x *NewBidder) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewBidder.ProtoReflect.Descriptor instead.
+func (*NewBidder) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NewBidder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NewBidder) GetCredentialHash() []byte {
+	if x != nil {
+		return x.CredentialHash
+	}
+	return nil
+}
+
+type BidderData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CredentialHash []byte                 `protobuf:"bytes,3,opt,name=credentialHash,proto3" json:"credentialHash,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BidderData) Reset() {
+	*x = BidderData{}
+	mi := &file_proto_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BidderData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BidderData) ProtoMessage() {}
+
+func (x *BidderData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BidderData.ProtoReflect.Descriptor instead.
+func (*BidderData) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BidderData) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BidderData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BidderData) GetCredentialHash() []byte {
+	if x != nil {
+		return x.CredentialHash
+	}
+	return nil
+}
+
+// decided by the leader when the next step of a dutch auction is due, ignored if the price is no longer from
+type PriceDrop struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     int32                  `protobuf:"varint,1,opt,name=auctionId,proto3" json:"auctionId,omitempty"`
+	From          int32                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int32                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceDrop) Reset() {
+	*x = PriceDrop{}
+	mi := &file_proto_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceDrop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceDrop) ProtoMessage() {}
+
+func (x *PriceDrop) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceDrop.ProtoReflect.Descriptor instead.
+func (*PriceDrop) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PriceDrop) GetAuctionId() int32 {
+	if x != nil {
+		return x.AuctionId
+	}
+	return 0
+}
+
+func (x *PriceDrop) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *PriceDrop) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// decided by the leader when the end time has passed, bids after it in the log are too late.
+// ignored if a bid has extended the auction past endTime in the meantime
+type CloseAuction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     int32                  `protobuf:"varint,1,opt,name=auctionId,proto3" json:"auctionId,omitempty"`
+	EndTime       int64                  `protobuf:"varint,2,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseAuction) Reset() {
+	*x = CloseAuction{}
+	mi := &file_proto_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAuction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAuction) ProtoMessage() {}
+
+func (x *CloseAuction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAuction.ProtoReflect.Descriptor instead.
+func (*CloseAuction) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CloseAuction) GetAuctionId() int32 {
+	if x != nil {
+		return x.AuctionId
+	}
+	return 0
+}
+
+func (x *CloseAuction) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+// the lamport time is set by the leader and moves the clock of every server that applies the entry
+type LogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int32                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Lamport       int32                  `protobuf:"varint,2,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	Command       *Command               `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Time          int64                  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"` //unix milliseconds on the leader when it was proposed, so deadlines are judged the same everywhere
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_proto_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LogEntry) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *LogEntry) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+func (x *LogEntry) GetCommand() *Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *LogEntry) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type AppendEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int32                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId      int32                  `protobuf:"varint,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	PrevLogIndex  int32                  `protobuf:"varint,3,opt,name=prevLogIndex,proto3" json:"prevLogIndex,omitempty"`
+	PrevLogTerm   int32                  `protobuf:"varint,4,opt,name=prevLogTerm,proto3" json:"prevLogTerm,omitempty"`
+	Entries       []*LogEntry            `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	LeaderCommit  int32                  `protobuf:"varint,6,opt,name=leaderCommit,proto3" json:"leaderCommit,omitempty"`
+	LeaderAddress string                 `protobuf:"bytes,7,opt,name=leaderAddress,proto3" json:"leaderAddress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendEntriesRequest) Reset() {
+	*x = AppendEntriesRequest{}
+	mi := &file_proto_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendEntriesRequest) ProtoMessage() {}
+
+func (x *AppendEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendEntriesRequest.ProtoReflect.Descriptor instead.
+func (*AppendEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AppendEntriesRequest) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetLeaderId() int32 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetPrevLogIndex() int32 {
+	if x != nil {
+		return x.PrevLogIndex
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetPrevLogTerm() int32 {
+	if x != nil {
+		return x.PrevLogTerm
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetEntries() []*LogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AppendEntriesRequest) GetLeaderCommit() int32 {
+	if x != nil {
+		return x.LeaderCommit
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetLeaderAddress() string {
+	if x != nil {
+		return x.LeaderAddress
+	}
+	return ""
+}
+
+type AppendEntriesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int32                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	LastLogIndex  int32                  `protobuf:"varint,3,opt,name=lastLogIndex,proto3" json:"lastLogIndex,omitempty"` //where the leader should continue from when success is false
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendEntriesReply) Reset() {
+	*x = AppendEntriesReply{}
+	mi := &file_proto_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendEntriesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendEntriesReply) ProtoMessage() {}
+
+func (x *AppendEntriesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendEntriesReply.ProtoReflect.Descriptor instead.
+func (*AppendEntriesReply) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AppendEntriesReply) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *AppendEntriesReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AppendEntriesReply) GetLastLogIndex() int32 {
+	if x != nil {
+		return x.LastLogIndex
+	}
+	return 0
+}
+
+type VoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int32                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	CandidateId   int32                  `protobuf:"varint,2,opt,name=candidateId,proto3" json:"candidateId,omitempty"`
+	LastLogIndex  int32                  `protobuf:"varint,3,opt,name=lastLogIndex,proto3" json:"lastLogIndex,omitempty"`
+	LastLogTerm   int32                  `protobuf:"varint,4,opt,name=lastLogTerm,proto3" json:"lastLogTerm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteRequest) Reset() {
+	*x = VoteRequest{}
+	mi := &file_proto_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteRequest) ProtoMessage() {}
+
+func (x *VoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteRequest.ProtoReflect.Descriptor instead.
+func (*VoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VoteRequest) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *VoteRequest) GetCandidateId() int32 {
+	if x != nil {
+		return x.CandidateId
+	}
+	return 0
+}
+
+func (x *VoteRequest) GetLastLogIndex() int32 {
+	if x != nil {
+		return x.LastLogIndex
+	}
+	return 0
+}
+
+func (x *VoteRequest) GetLastLogTerm() int32 {
+	if x != nil {
+		return x.LastLogTerm
+	}
+	return 0
+}
+
+type VoteReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int32                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	VoteGranted   bool                   `protobuf:"varint,2,opt,name=voteGranted,proto3" json:"voteGranted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteReply) Reset() {
+	*x = VoteReply{}
+	mi := &file_proto_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteReply) ProtoMessage() {}
+
+func (x *VoteReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteReply.ProtoReflect.Descriptor instead.
+func (*VoteReply) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VoteReply) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *VoteReply) GetVoteGranted() bool {
+	if x != nil {
+		return x.VoteGranted
+	}
+	return false
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int32                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId      int32                  `protobuf:"varint,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	LeaderAddress string                 `protobuf:"bytes,3,opt,name=leaderAddress,proto3" json:"leaderAddress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_proto_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *HeartbeatRequest) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetLeaderId() int32 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetLeaderAddress() string {
+	if x != nil {
+		return x.LeaderAddress
+	}
+	return ""
+}
+
+type HeartbeatReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int32                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatReply) Reset() {
+	*x = HeartbeatReply{}
+	mi := &file_proto_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatReply) ProtoMessage() {}
+
+func (x *HeartbeatReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatReply.ProtoReflect.Descriptor instead.
+func (*HeartbeatReply) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *HeartbeatReply) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *HeartbeatReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type SyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` //the address the leader knows the server by
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncRequest) Reset() {
+	*x = SyncRequest{}
+	mi := &file_proto_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRequest) ProtoMessage() {}
+
+func (x *SyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRequest.ProtoReflect.Descriptor instead.
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SyncRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SyncRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type AuctionData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Item           string                 `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	StartTime      int64                  `protobuf:"varint,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime        int64                  `protobuf:"varint,4,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Closed         bool                   `protobuf:"varint,5,opt,name=closed,proto3" json:"closed,omitempty"`
+	HighestBid     int32                  `protobuf:"varint,6,opt,name=highestBid,proto3" json:"highestBid,omitempty"`
+	HighestBidder  int32                  `protobuf:"varint,7,opt,name=highestBidder,proto3" json:"highestBidder,omitempty"`
+	ExtendWithin   int32                  `protobuf:"varint,8,opt,name=extendWithin,proto3" json:"extendWithin,omitempty"`
+	ExtendBy       int32                  `protobuf:"varint,9,opt,name=extendBy,proto3" json:"extendBy,omitempty"`
+	Mode           string                 `protobuf:"bytes,10,opt,name=mode,proto3" json:"mode,omitempty"`
+	SealedBids     []*SealedBid           `protobuf:"bytes,11,rep,name=sealedBids,proto3" json:"sealedBids,omitempty"`
+	Price          int32                  `protobuf:"varint,12,opt,name=price,proto3" json:"price,omitempty"`
+	PriceStep      int32                  `protobuf:"varint,13,opt,name=priceStep,proto3" json:"priceStep,omitempty"`
+	StepEvery      int32                  `protobuf:"varint,14,opt,name=stepEvery,proto3" json:"stepEvery,omitempty"`
+	FloorPrice     int32                  `protobuf:"varint,15,opt,name=floorPrice,proto3" json:"floorPrice,omitempty"`
+	PriceDroppedAt int64                  `protobuf:"varint,16,opt,name=priceDroppedAt,proto3" json:"priceDroppedAt,omitempty"`
+	ReservePrice   int32                  `protobuf:"varint,17,opt,name=reservePrice,proto3" json:"reservePrice,omitempty"`
+	BuyNowPrice    int32                  `protobuf:"varint,18,opt,name=buyNowPrice,proto3" json:"buyNowPrice,omitempty"`
+	Increment      *IncrementRule         `protobuf:"bytes,19,opt,name=increment,proto3" json:"increment,omitempty"`
+	Proxies        []*ProxyBid            `protobuf:"bytes,20,rep,name=proxies,proto3" json:"proxies,omitempty"`
+	History        []*BidRecord           `protobuf:"bytes,21,rep,name=history,proto3" json:"history,omitempty"`
+	Requests       []*AnsweredRequest     `protobuf:"bytes,22,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuctionData) Reset() {
+	*x = AuctionData{}
+	mi := &file_proto_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionData) ProtoMessage() {}
+
+func (x *AuctionData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionData.ProtoReflect.Descriptor instead.
+func (*AuctionData) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AuctionData) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuctionData) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *AuctionData) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *AuctionData) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *AuctionData) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *AuctionData) GetHighestBid() int32 {
+	if x != nil {
+		return x.HighestBid
+	}
+	return 0
+}
+
+func (x *AuctionData) GetHighestBidder() int32 {
+	if x != nil {
+		return x.HighestBidder
+	}
+	return 0
+}
+
+func (x *AuctionData) GetExtendWithin() int32 {
+	if x != nil {
+		return x.ExtendWithin
+	}
+	return 0
+}
+
+func (x *AuctionData) GetExtendBy() int32 {
+	if x != nil {
+		return x.ExtendBy
+	}
+	return 0
+}
+
+func (x *AuctionData) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *AuctionData) GetSealedBids() []*SealedBid {
+	if x != nil {
+		return x.SealedBids
+	}
+	return nil
+}
+
+func (x *AuctionData) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AuctionData) GetPriceStep() int32 {
+	if x != nil {
+		return x.PriceStep
+	}
+	return 0
+}
+
+func (x *AuctionData) GetStepEvery() int32 {
+	if x != nil {
+		return x.StepEvery
+	}
+	return 0
+}
+
+func (x *AuctionData) GetFloorPrice() int32 {
+	if x != nil {
+		return x.FloorPrice
+	}
+	return 0
+}
+
+func (x *AuctionData) GetPriceDroppedAt() int64 {
+	if x != nil {
+		return x.PriceDroppedAt
+	}
+	return 0
+}
+
+func (x *AuctionData) GetReservePrice() int32 {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return 0
+}
+
+func (x *AuctionData) GetBuyNowPrice() int32 {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return 0
+}
+
+func (x *AuctionData) GetIncrement() *IncrementRule {
+	if x != nil {
+		return x.Increment
+	}
+	return nil
+}
+
+func (x *AuctionData) GetProxies() []*ProxyBid {
+	if x != nil {
+		return x.Proxies
+	}
+	return nil
+}
+
+func (x *AuctionData) GetHistory() []*BidRecord {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *AuctionData) GetRequests() []*AnsweredRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// the answer to a bid with a request id, kept so a retry of it is not applied again
+type AnsweredRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bidder        int32                  `protobuf:"varint,1,opt,name=bidder,proto3" json:"bidder,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	Ack           *Ack                   `protobuf:"bytes,3,opt,name=ack,proto3" json:"ack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnsweredRequest) Reset() {
+	*x = AnsweredRequest{}
+	mi := &file_proto_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnsweredRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnsweredRequest) ProtoMessage() {}
+
+func (x *AnsweredRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnsweredRequest.ProtoReflect.Descriptor instead.
+func (*AnsweredRequest) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AnsweredRequest) GetBidder() int32 {
+	if x != nil {
+		return x.Bidder
+	}
+	return 0
+}
+
+func (x *AnsweredRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AnsweredRequest) GetAck() *Ack {
+	if x != nil {
+		return x.Ack
+	}
+	return nil
+}
+
+// one attempt to buy the item
+type BidRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bidder        int32                  `protobuf:"varint,1,opt,name=bidder,proto3" json:"bidder,omitempty"`
+	Amount        int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"` //0 for sealed and maximum bids while the auction is open
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`      //bid, max, proxy (raised by a maximum bid) or accept
+	Outcome       BidOutcome             `protobuf:"varint,4,opt,name=outcome,proto3,enum=BidOutcome" json:"outcome,omitempty"`
+	Lamport       int32                  `protobuf:"varint,5,opt,name=lamport,proto3" json:"lamport,omitempty"` //of the log entry, the same on every server
+	Time          int64                  `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`       //unix milliseconds, when the leader received it
+	Reason        Reason                 `protobuf:"varint,7,opt,name=reason,proto3,enum=Reason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BidRecord) Reset() {
+	*x = BidRecord{}
+	mi := &file_proto_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BidRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BidRecord) ProtoMessage() {}
+
+func (x *BidRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BidRecord.ProtoReflect.Descriptor instead.
+func (*BidRecord) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BidRecord) GetBidder() int32 {
+	if x != nil {
+		return x.Bidder
+	}
+	return 0
+}
+
+func (x *BidRecord) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BidRecord) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *BidRecord) GetOutcome() BidOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return BidOutcome_OUTCOME_UNSPECIFIED
+}
+
+func (x *BidRecord) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+func (x *BidRecord) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *BidRecord) GetReason() Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return Reason_NO_REASON
+}
+
+// a secret maximum the server bids up to for the bidder
+type ProxyBid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bidder        int32                  `protobuf:"varint,1,opt,name=bidder,proto3" json:"bidder,omitempty"`
+	Maximum       int32                  `protobuf:"varint,2,opt,name=maximum,proto3" json:"maximum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProxyBid) Reset() {
+	*x = ProxyBid{}
+	mi := &file_proto_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyBid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyBid) ProtoMessage() {}
+
+func (x *ProxyBid) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProxyBid.ProtoReflect.Descriptor instead.
+func (*ProxyBid) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ProxyBid) GetBidder() int32 {
+	if x != nil {
+		return x.Bidder
+	}
+	return 0
+}
+
+func (x *ProxyBid) GetMaximum() int32 {
+	if x != nil {
+		return x.Maximum
+	}
+	return 0
+}
+
+type SealedBid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bidder        int32                  `protobuf:"varint,1,opt,name=bidder,proto3" json:"bidder,omitempty"`
+	Amount        int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SealedBid) Reset() {
+	*x = SealedBid{}
+	mi := &file_proto_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SealedBid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SealedBid) ProtoMessage() {}
+
+func (x *SealedBid) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SealedBid.ProtoReflect.Descriptor instead.
+func (*SealedBid) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SealedBid) GetBidder() int32 {
+	if x != nil {
+		return x.Bidder
+	}
+	return 0
+}
+
+func (x *SealedBid) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// the full state of every auction after the log entry at lastIndex has been applied
+type Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastIndex     int32                  `protobuf:"varint,1,opt,name=lastIndex,proto3" json:"lastIndex,omitempty"`
+	LastTerm      int32                  `protobuf:"varint,2,opt,name=lastTerm,proto3" json:"lastTerm,omitempty"`
+	Lamport       int32                  `protobuf:"varint,3,opt,name=lamport,proto3" json:"lamport,omitempty"`
+	Auctions      []*AuctionData         `protobuf:"bytes,4,rep,name=auctions,proto3" json:"auctions,omitempty"`
+	NextAuctionId int32                  `protobuf:"varint,5,opt,name=nextAuctionId,proto3" json:"nextAuctionId,omitempty"`
+	Bidders       []*BidderData          `protobuf:"bytes,6,rep,name=bidders,proto3" json:"bidders,omitempty"`
+	NextBidderId  int32                  `protobuf:"varint,7,opt,name=nextBidderId,proto3" json:"nextBidderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_proto_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Snapshot) GetLastIndex() int32 {
+	if x != nil {
+		return x.LastIndex
+	}
+	return 0
+}
+
+func (x *Snapshot) GetLastTerm() int32 {
+	if x != nil {
+		return x.LastTerm
+	}
+	return 0
+}
+
+func (x *Snapshot) GetLamport() int32 {
+	if x != nil {
+		return x.Lamport
+	}
+	return 0
+}
+
+func (x *Snapshot) GetAuctions() []*AuctionData {
+	if x != nil {
+		return x.Auctions
+	}
+	return nil
+}
+
+func (x *Snapshot) GetNextAuctionId() int32 {
+	if x != nil {
+		return x.NextAuctionId
+	}
+	return 0
+}
+
+func (x *Snapshot) GetBidders() []*BidderData {
+	if x != nil {
+		return x.Bidders
+	}
+	return nil
+}
+
+func (x *Snapshot) GetNextBidderId() int32 {
+	if x != nil {
+		return x.NextBidderId
+	}
+	return 0
+}
+
+// a change to the raft state, appended to the write-ahead log under -data-dir before the server acts on it
+type WalRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int32                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	VotedFor      int32                  `protobuf:"varint,2,opt,name=votedFor,proto3" json:"votedFor,omitempty"`
+	CommitIndex   int32                  `protobuf:"varint,3,opt,name=commitIndex,proto3" json:"commitIndex,omitempty"`
+	Index         int32                  `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"` //index of the first of the entries, any entries from there on are replaced. 0 if there are none
+	Entries       []*LogEntry            `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	Snapshot      *Snapshot              `protobuf:"bytes,6,opt,name=snapshot,proto3" json:"snapshot,omitempty"` //state pulled from the leader, replaces the log up to its lastIndex
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalRecord) Reset() {
+	*x = WalRecord{}
+	mi := &file_proto_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalRecord) ProtoMessage() {}
+
+func (x *WalRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalRecord.ProtoReflect.Descriptor instead.
+func (*WalRecord) Descriptor() ([]byte, []int) {
+	return file_proto_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *WalRecord) GetTerm() int32 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *WalRecord) GetVotedFor() int32 {
+	if x != nil {
+		return x.VotedFor
+	}
+	return 0
+}
+
+func (x *WalRecord) GetCommitIndex() int32 {
+	if x != nil {
+		return x.CommitIndex
+	}
+	return 0
+}
+
+func (x *WalRecord) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *WalRecord) GetEntries() []*LogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *WalRecord) GetSnapshot() *Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+var File_proto_proto protoreflect.FileDescriptor
+
+const file_proto_proto_rawDesc = "" +
+	"\n" +
+	"\vproto.proto\"\xaa\x01\n" +
+	"\x06Amount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\alamport\x18\x02 \x01(\x05R\alamport\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\x12\"\n" +
+	"\famountOfBids\x18\x04 \x01(\x05R\famountOfBids\x12\x1c\n" +
+	"\tauctionId\x18\x05 \x01(\x05R\tauctionId\x12\x1c\n" +
+	"\trequestId\x18\x06 \x01(\tR\trequestId\"\xc5\x01\n" +
+	"\tRejection\x12%\n" +
+	"\aoutcome\x18\x01 \x01(\x0e2\v.BidOutcomeR\aoutcome\x12\x1f\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\a.ReasonR\x06reason\x12\x16\n" +
+	"\x06minBid\x18\x03 \x01(\x05R\x06minBid\x12\x18\n" +
+	"\aendTime\x18\x04 \x01(\x03R\aendTime\x12\x18\n" +
+	"\alamport\x18\x05 \x01(\x05R\alamport\x12$\n" +
+	"\rleaderAddress\x18\x06 \x01(\tR\rleaderAddress\"\xc7\x01\n" +
+	"\x03Ack\x12%\n" +
+	"\aoutcome\x18\x01 \x01(\x0e2\v.BidOutcomeR\aoutcome\x12\x18\n" +
+	"\alamport\x18\x02 \x01(\x05R\alamport\x12\x18\n" +
+	"\aendTime\x18\x03 \x01(\x03R\aendTime\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x05R\x05price\x12\x16\n" +
+	"\x06minBid\x18\x05 \x01(\x05R\x06minBid\x12\x16\n" +
+	"\x06outbid\x18\x06 \x01(\bR\x06outbid\x12\x1f\n" +
+	"\x06reason\x18\a \x01(\x0e2\a.ReasonR\x06reason\"?\n" +
+	"\x05Empty\x12\x18\n" +
+	"\alamport\x18\x01 \x01(\x05R\alamport\x12\x1c\n" +
+	"\tauctionId\x18\x02 \x01(\x05R\tauctionId\"\xeb\x02\n" +
+	"\aOutcome\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1e\n" +
+	"\n" +
+	"highestBid\x18\x02 \x01(\x05R\n" +
+	"highestBid\x12\"\n" +
+	"\factionClosed\x18\x03 \x01(\bR\factionClosed\x12\x18\n" +
+	"\alamport\x18\x04 \x01(\x05R\alamport\x12\x1c\n" +
+	"\tauctionId\x18\x05 \x01(\x05R\tauctionId\x12\x12\n" +
+	"\x04item\x18\x06 \x01(\tR\x04item\x12\x1c\n" +
+	"\tstartTime\x18\a \x01(\x03R\tstartTime\x12\x18\n" +
+	"\aendTime\x18\b \x01(\x03R\aendTime\x12\x12\n" +
+	"\x04mode\x18\t \x01(\tR\x04mode\x12\x14\n" +
+	"\x05price\x18\n" +
+	" \x01(\x05R\x05price\x12$\n" +
+	"\rreserveNotMet\x18\v \x01(\bR\rreserveNotMet\x12 \n" +
+	"\vbuyNowPrice\x18\f \x01(\x05R\vbuyNowPrice\x12\x16\n" +
+	"\x06minBid\x18\r \x01(\x05R\x06minBid\"v\n" +
+	"\x0eHistoryRequest\x12\x1c\n" +
+	"\tauctionId\x18\x01 \x01(\x05R\tauctionId\x12\x18\n" +
+	"\alamport\x18\x02 \x01(\x05R\alamport\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x83\x01\n" +
+	"\vHistoryPage\x12$\n" +
+	"\arecords\x18\x01 \x03(\v2\n" +
+	".BidRecordR\arecords\x12\x1e\n" +
+	"\n" +
+	"nextOffset\x18\x02 \x01(\x05R\n" +
+	"nextOffset\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x18\n" +
+	"\alamport\x18\x04 \x01(\x05R\alamport\"Z\n" +
+	"\x06Update\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\alamport\x18\x02 \x01(\x05R\alamport\x12\"\n" +
+	"\aoutcome\x18\x03 \x01(\v2\b.OutcomeR\aoutcome\"\xd2\x03\n" +
+	"\n" +
+	"NewAuction\x12\x12\n" +
+	"\x04item\x18\x01 \x01(\tR\x04item\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x05R\bduration\x12\x18\n" +
+	"\alamport\x18\x03 \x01(\x05R\alamport\x12\x1c\n" +
+	"\tstartTime\x18\x04 \x01(\x03R\tstartTime\x12\x18\n" +
+	"\aendTime\x18\x05 \x01(\x03R\aendTime\x12\"\n" +
+	"\fextendWithin\x18\x06 \x01(\x05R\fextendWithin\x12\x1a\n" +
+	"\bextendBy\x18\a \x01(\x05R\bextendBy\x12\x12\n" +
+	"\x04mode\x18\b \x01(\tR\x04mode\x12\x1e\n" +
+	"\n" +
+	"startPrice\x18\t \x01(\x05R\n" +
+	"startPrice\x12\x1c\n" +
+	"\tpriceStep\x18\n" +
+	" \x01(\x05R\tpriceStep\x12\x1c\n" +
+	"\tstepEvery\x18\v \x01(\x05R\tstepEvery\x12\x1e\n" +
+	"\n" +
+	"floorPrice\x18\f \x01(\x05R\n" +
+	"floorPrice\x12\"\n" +
+	"\freservePrice\x18\r \x01(\x05R\freservePrice\x12 \n" +
+	"\vbuyNowPrice\x18\x0e \x01(\x05R\vbuyNowPrice\x12,\n" +
+	"\tincrement\x18\x0f \x01(\v2\x0e.IncrementRuleR\tincrement\"e\n" +
+	"\rIncrementRule\x12\x14\n" +
+	"\x05fixed\x18\x01 \x01(\x05R\x05fixed\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x05R\apercent\x12$\n" +
+	"\x05tiers\x18\x03 \x03(\v2\x0e.IncrementTierR\x05tiers\"7\n" +
+	"\rIncrementTier\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x05R\x04from\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x05R\x04step\"r\n" +
+	"\n" +
+	"Acceptance\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\alamport\x18\x02 \x01(\x05R\alamport\x12\x1c\n" +
+	"\tauctionId\x18\x03 \x01(\x05R\tauctionId\x12\x1c\n" +
+	"\trequestId\x18\x04 \x01(\tR\trequestId\"H\n" +
+	"\x0eAuctionCreated\x12\x1c\n" +
+	"\tauctionId\x18\x01 \x01(\x05R\tauctionId\x12\x18\n" +
+	"\alamport\x18\x02 \x01(\x05R\alamport\"\x92\x02\n" +
+	"\aCommand\x12\x1b\n" +
+	"\x03bid\x18\x01 \x01(\v2\a.AmountH\x00R\x03bid\x12%\n" +
+	"\x06create\x18\x02 \x01(\v2\v.NewAuctionH\x00R\x06create\x12%\n" +
+	"\x05close\x18\x03 \x01(\v2\r.CloseAuctionH\x00R\x05close\x12 \n" +
+	"\x04drop\x18\x04 \x01(\v2\n" +
+	".PriceDropH\x00R\x04drop\x12%\n" +
+	"\x06accept\x18\x05 \x01(\v2\v.AcceptanceH\x00R\x06accept\x12!\n" +
+	"\x06maxBid\x18\x06 \x01(\v2\a.AmountH\x00R\x06maxBid\x12(\n" +
+	"\bregister\x18\a \x01(\v2\n" +
+	".NewBidderH\x00R\bregisterB\x06\n" +
+	"\x04kind\"<\n" +
+	"\fRegistration\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\alamport\x18\x02 \x01(\x05R\alamport\"V\n" +
+	"\n" +
+	"Registered\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\tR\n" +
+	"credential\x12\x18\n" +
+	"\alamport\x18\x03 \x01(\x05R\alamport\"G\n" +
+	"\tNewBidder\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12&\n" +
+	"\x0ecredentialHash\x18\x02 \x01(\fR\x0ecredentialHash\"X\n" +
+	"\n" +
+	"BidderData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12&\n" +
+	"\x0ecredentialHash\x18\x03 \x01(\fR\x0ecredentialHash\"M\n" +
+	"\tPriceDrop\x12\x1c\n" +
+	"\tauctionId\x18\x01 \x01(\x05R\tauctionId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x05R\x02to\"F\n" +
+	"\fCloseAuction\x12\x1c\n" +
+	"\tauctionId\x18\x01 \x01(\x05R\tauctionId\x12\x18\n" +
+	"\aendTime\x18\x02 \x01(\x03R\aendTime\"p\n" +
+	"\bLogEntry\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x05R\x04term\x12\x18\n" +
+	"\alamport\x18\x02 \x01(\x05R\alamport\x12\"\n" +
+	"\acommand\x18\x03 \x01(\v2\b.CommandR\acommand\x12\x12\n" +
+	"\x04time\x18\x04 \x01(\x03R\x04time\"\xfb\x01\n" +
+	"\x14AppendEntriesRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x05R\x04term\x12\x1a\n" +
+	"\bleaderId\x18\x02 \x01(\x05R\bleaderId\x12\"\n" +
+	"\fprevLogIndex\x18\x03 \x01(\x05R\fprevLogIndex\x12 \n" +
+	"\vprevLogTerm\x18\x04 \x01(\x05R\vprevLogTerm\x12#\n" +
+	"\aentries\x18\x05 \x03(\v2\t.LogEntryR\aentries\x12\"\n" +
+	"\fleaderCommit\x18\x06 \x01(\x05R\fleaderCommit\x12$\n" +
+	"\rleaderAddress\x18\a \x01(\tR\rleaderAddress\"f\n" +
+	"\x12AppendEntriesReply\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x05R\x04term\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\"\n" +
+	"\flastLogIndex\x18\x03 \x01(\x05R\flastLogIndex\"\x89\x01\n" +
+	"\vVoteRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x05R\x04term\x12 \n" +
+	"\vcandidateId\x18\x02 \x01(\x05R\vcandidateId\x12\"\n" +
+	"\flastLogIndex\x18\x03 \x01(\x05R\flastLogIndex\x12 \n" +
+	"\vlastLogTerm\x18\x04 \x01(\x05R\vlastLogTerm\"A\n" +
+	"\tVoteReply\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x05R\x04term\x12 \n" +
+	"\vvoteGranted\x18\x02 \x01(\bR\vvoteGranted\"h\n" +
+	"\x10HeartbeatRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x05R\x04term\x12\x1a\n" +
+	"\bleaderId\x18\x02 \x01(\x05R\bleaderId\x12$\n" +
+	"\rleaderAddress\x18\x03 \x01(\tR\rleaderAddress\">\n" +
+	"\x0eHeartbeatReply\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x05R\x04term\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"7\n" +
+	"\vSyncRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"\xce\x05\n" +
+	"\vAuctionData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04item\x18\x02 \x01(\tR\x04item\x12\x1c\n" +
+	"\tstartTime\x18\x03 \x01(\x03R\tstartTime\x12\x18\n" +
+	"\aendTime\x18\x04 \x01(\x03R\aendTime\x12\x16\n" +
+	"\x06closed\x18\x05 \x01(\bR\x06closed\x12\x1e\n" +
+	"\n" +
+	"highestBid\x18\x06 \x01(\x05R\n" +
+	"highestBid\x12$\n" +
+	"\rhighestBidder\x18\a \x01(\x05R\rhighestBidder\x12\"\n" +
+	"\fextendWithin\x18\b \x01(\x05R\fextendWithin\x12\x1a\n" +
+	"\bextendBy\x18\t \x01(\x05R\bextendBy\x12\x12\n" +
+	"\x04mode\x18\n" +
+	" \x01(\tR\x04mode\x12*\n" +
+	"\n" +
+	"sealedBids\x18\v \x03(\v2\n" +
+	".SealedBidR\n" +
+	"sealedBids\x12\x14\n" +
+	"\x05price\x18\f \x01(\x05R\x05price\x12\x1c\n" +
+	"\tpriceStep\x18\r \x01(\x05R\tpriceStep\x12\x1c\n" +
+	"\tstepEvery\x18\x0e \x01(\x05R\tstepEvery\x12\x1e\n" +
+	"\n" +
+	"floorPrice\x18\x0f \x01(\x05R\n" +
+	"floorPrice\x12&\n" +
+	"\x0epriceDroppedAt\x18\x10 \x01(\x03R\x0epriceDroppedAt\x12\"\n" +
+	"\freservePrice\x18\x11 \x01(\x05R\freservePrice\x12 \n" +
+	"\vbuyNowPrice\x18\x12 \x01(\x05R\vbuyNowPrice\x12,\n" +
+	"\tincrement\x18\x13 \x01(\v2\x0e.IncrementRuleR\tincrement\x12#\n" +
+	"\aproxies\x18\x14 \x03(\v2\t.ProxyBidR\aproxies\x12$\n" +
+	"\ahistory\x18\x15 \x03(\v2\n" +
+	".BidRecordR\ahistory\x12,\n" +
+	"\brequests\x18\x16 \x03(\v2\x10.AnsweredRequestR\brequests\"_\n" +
+	"\x0fAnsweredRequest\x12\x16\n" +
+	"\x06bidder\x18\x01 \x01(\x05R\x06bidder\x12\x1c\n" +
+	"\trequestId\x18\x02 \x01(\tR\trequestId\x12\x16\n" +
+	"\x03ack\x18\x03 \x01(\v2\x04.AckR\x03ack\"\xc5\x01\n" +
+	"\tBidRecord\x12\x16\n" +
+	"\x06bidder\x18\x01 \x01(\x05R\x06bidder\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12%\n" +
+	"\aoutcome\x18\x04 \x01(\x0e2\v.BidOutcomeR\aoutcome\x12\x18\n" +
+	"\alamport\x18\x05 \x01(\x05R\alamport\x12\x12\n" +
+	"\x04time\x18\x06 \x01(\x03R\x04time\x12\x1f\n" +
+	"\x06reason\x18\a \x01(\x0e2\a.ReasonR\x06reason\"<\n" +
+	"\bProxyBid\x12\x16\n" +
+	"\x06bidder\x18\x01 \x01(\x05R\x06bidder\x12\x18\n" +
+	"\amaximum\x18\x02 \x01(\x05R\amaximum\";\n" +
+	"\tSealedBid\x12\x16\n" +
+	"\x06bidder\x18\x01 \x01(\x05R\x06bidder\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\"\xf9\x01\n" +
+	"\bSnapshot\x12\x1c\n" +
+	"\tlastIndex\x18\x01 \x01(\x05R\tlastIndex\x12\x1a\n" +
+	"\blastTerm\x18\x02 \x01(\x05R\blastTerm\x12\x18\n" +
+	"\alamport\x18\x03 \x01(\x05R\alamport\x12(\n" +
+	"\bauctions\x18\x04 \x03(\v2\f.AuctionDataR\bauctions\x12$\n" +
+	"\rnextAuctionId\x18\x05 \x01(\x05R\rnextAuctionId\x12%\n" +
+	"\abidders\x18\x06 \x03(\v2\v.BidderDataR\abidders\x12\"\n" +
+	"\fnextBidderId\x18\a \x01(\x05R\fnextBidderId\"\xbf\x01\n" +
+	"\tWalRecord\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x05R\x04term\x12\x1a\n" +
+	"\bvotedFor\x18\x02 \x01(\x05R\bvotedFor\x12 \n" +
+	"\vcommitIndex\x18\x03 \x01(\x05R\vcommitIndex\x12\x14\n" +
+	"\x05index\x18\x04 \x01(\x05R\x05index\x12#\n" +
+	"\aentries\x18\x05 \x03(\v2\t.LogEntryR\aentries\x12%\n" +
+	"\bsnapshot\x18\x06 \x01(\v2\t.SnapshotR\bsnapshot*K\n" +
+	"\n" +
+	"BidOutcome\x12\x17\n" +
+	"\x13OUTCOME_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aSUCCESS\x10\x01\x12\b\n" +
+	"\x04FAIL\x10\x02\x12\r\n" +
+	"\tEXCEPTION\x10\x03*\xeb\x01\n" +
+	"\x06Reason\x12\r\n" +
+	"\tNO_REASON\x10\x00\x12\x11\n" +
+	"\rBELOW_CURRENT\x10\x01\x12\x12\n" +
+	"\x0eAUCTION_CLOSED\x10\x02\x12\x12\n" +
+	"\x0eNOT_REGISTERED\x10\x03\x12\x17\n" +
+	"\x13INCREMENT_TOO_SMALL\x10\x04\x12\x0e\n" +
+	"\n" +
+	"NOT_LEADER\x10\x05\x12\x0f\n" +
+	"\vNOT_STARTED\x10\x06\x12\x0f\n" +
+	"\vALREADY_BID\x10\a\x12\x0e\n" +
+	"\n" +
+	"WRONG_MODE\x10\b\x12\x13\n" +
+	"\x0fNO_SUCH_AUCTION\x10\t\x12\x14\n" +
+	"\x10WRONG_CREDENTIAL\x10\n" +
+	"\x12\x11\n" +
+	"\rNOT_COMMITTED\x10\v2\x93\x02\n" +
+	"\aAuction\x12&\n" +
+	"\bRegister\x12\r.Registration\x1a\v.Registered\x12-\n" +
+	"\rCreateAuction\x12\v.NewAuction\x1a\x0f.AuctionCreated\x12\x14\n" +
+	"\x03Bid\x12\a.Amount\x1a\x04.Ack\x12\x1b\n" +
+	"\x06Accept\x12\v.Acceptance\x1a\x04.Ack\x12\x1c\n" +
+	"\vPlaceMaxBid\x12\a.Amount\x1a\x04.Ack\x12\x1a\n" +
+	"\x06Result\x12\x06.Empty\x1a\b.Outcome\x12\x1a\n" +
+	"\x05Watch\x12\x06.Empty\x1a\a.Update0\x01\x12(\n" +
+	"\aHistory\x12\x0f.HistoryRequest\x1a\f.HistoryPage2\xca\x01\n" +
+	"\vReplication\x12;\n" +
+	"\rAppendEntries\x12\x15.AppendEntriesRequest\x1a\x13.AppendEntriesReply\x12'\n" +
+	"\vRequestVote\x12\f.VoteRequest\x1a\n" +
+	".VoteReply\x12/\n" +
+	"\tHeartbeat\x12\x11.HeartbeatRequest\x1a\x0f.HeartbeatReply\x12$\n" +
+	"\tSyncState\x12\f.SyncRequest\x1a\t.SnapshotB\x10Z\x0eHW5/grpc/protob\x06proto3"
+
+var (
+	file_proto_proto_rawDescOnce sync.Once
+	file_proto_proto_rawDescData []byte
+)
+
+func file_proto_proto_rawDescGZIP() []byte {
+	file_proto_proto_rawDescOnce.Do(func() {
+		file_proto_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_proto_rawDesc), len(file_proto_proto_rawDesc)))
+	})
+	return file_proto_proto_rawDescData
+}
+
+var file_proto_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_proto_proto_goTypes = []any{
+	(BidOutcome)(0),              // 0: BidOutcome
+	(Reason)(0),                  // 1: Reason
+	(*Amount)(nil),               // 2: Amount
+	(*Rejection)(nil),            // 3: Rejection
+	(*Ack)(nil),                  // 4: Ack
+	(*Empty)(nil),                // 5: Empty
+	(*Outcome)(nil),              // 6: Outcome
+	(*HistoryRequest)(nil),       // 7: HistoryRequest
+	(*HistoryPage)(nil),          // 8: HistoryPage
+	(*Update)(nil),               // 9: Update
+	(*NewAuction)(nil),           // 10: NewAuction
+	(*IncrementRule)(nil),        // 11: IncrementRule
+	(*IncrementTier)(nil),        // 12: IncrementTier
+	(*Acceptance)(nil),           // 13: Acceptance
+	(*AuctionCreated)(nil),       // 14: AuctionCreated
+	(*Command)(nil),              // 15: Command
+	(*Registration)(nil),         // 16: Registration
+	(*Registered)(nil),           // 17: Registered
+	(*NewBidder)(nil),            // 18: NewBidder
+	(*BidderData)(nil),           // 19: BidderData
+	(*PriceDrop)(nil),            // 20: PriceDrop
+	(*CloseAuction)(nil),         // 21: CloseAuction
+	(*LogEntry)(nil),             // 22: LogEntry
+	(*AppendEntriesRequest)(nil), // 23: AppendEntriesRequest
+	(*AppendEntriesReply)(nil),   // 24: AppendEntriesReply
+	(*VoteRequest)(nil),          // 25: VoteRequest
+	(*VoteReply)(nil),            // 26: VoteReply
+	(*HeartbeatRequest)(nil),     // 27: HeartbeatRequest
+	(*HeartbeatReply)(nil),       // 28: HeartbeatReply
+	(*SyncRequest)(nil),          // 29: SyncRequest
+	(*AuctionData)(nil),          // 30: AuctionData
+	(*AnsweredRequest)(nil),      // 31: AnsweredRequest
+	(*BidRecord)(nil),            // 32: BidRecord
+	(*ProxyBid)(nil),             // 33: ProxyBid
+	(*SealedBid)(nil),            // 34: SealedBid
+	(*Snapshot)(nil),             // 35: Snapshot
+	(*WalRecord)(nil),            // 36: WalRecord
+}
+var file_proto_proto_depIdxs = []int32{
+	0,  // 0: Rejection.outcome:type_name -> BidOutcome
+	1,  // 1: Rejection.reason:type_name -> Reason
+	0,  // 2: Ack.outcome:type_name -> BidOutcome
+	1,  // 3: Ack.reason:type_name -> Reason
+	32, // 4: HistoryPage.records:type_name -> BidRecord
+	6,  // 5: Update.outcome:type_name -> Outcome
+	11, // 6: NewAuction.increment:type_name -> IncrementRule
+	12, // 7: IncrementRule.tiers:type_name -> IncrementTier
+	2,  // 8: Command.bid:type_name -> Amount
+	10, // 9: Command.create:type_name -> NewAuction
+	21, // 10: Command.close:type_name -> CloseAuction
+	20, // 11: Command.drop:type_name -> PriceDrop
+	13, // 12: Command.accept:type_name -> Acceptance
+	2,  // 13: Command.maxBid:type_name -> Amount
+	18, // 14: Command.register:type_name -> NewBidder
+	15, // 15: LogEntry.command:type_name -> Command
+	22, // 16: AppendEntriesRequest.entries:type_name -> LogEntry
+	34, // 17: AuctionData.sealedBids:type_name -> SealedBid
+	11, // 18: AuctionData.increment:type_name -> IncrementRule
+	33, // 19: AuctionData.proxies:type_name -> ProxyBid
+	32, // 20: AuctionData.history:type_name -> BidRecord
+	31, // 21: AuctionData.requests:type_name -> AnsweredRequest
+	4,  // 22: AnsweredRequest.ack:type_name -> Ack
+	0,  // 23: BidRecord.outcome:type_name -> BidOutcome
+	1,  // 24: BidRecord.reason:type_name -> Reason
+	30, // 25: Snapshot.auctions:type_name -> AuctionData
+	19, // 26: Snapshot.bidders:type_name -> BidderData
+	22, // 27: WalRecord.entries:type_name -> LogEntry
+	35, // 28: WalRecord.snapshot:type_name -> Snapshot
+	16, // 29: Auction.Register:input_type -> Registration
+	10, // 30: Auction.CreateAuction:input_type -> NewAuction
+	2,  // 31: Auction.Bid:input_type -> Amount
+	13, // 32: Auction.Accept:input_type -> Acceptance
+	2,  // 33: Auction.PlaceMaxBid:input_type -> Amount
+	5,  // 34: Auction.Result:input_type -> Empty
+	5,  // 35: Auction.Watch:input_type -> Empty
+	7,  // 36: Auction.History:input_type -> HistoryRequest
+	23, // 37: Replication.AppendEntries:input_type -> AppendEntriesRequest
+	25, // 38: Replication.RequestVote:input_type -> VoteRequest
+	27, // 39: Replication.Heartbeat:input_type -> HeartbeatRequest
+	29, // 40: Replication.SyncState:input_type -> SyncRequest
+	17, // 41: Auction.Register:output_type -> Registered
+	14, // 42: Auction.CreateAuction:output_type -> AuctionCreated
+	4,  // 43: Auction.Bid:output_type -> Ack
+	4,  // 44: Auction.Accept:output_type -> Ack
+	4,  // 45: Auction.PlaceMaxBid:output_type -> Ack
+	6,  // 46: Auction.Result:output_type -> Outcome
+	9,  // 47: Auction.Watch:output_type -> Update
+	8,  // 48: Auction.History:output_type -> HistoryPage
+	24, // 49: Replication.AppendEntries:output_type -> AppendEntriesReply
+	26, // 50: Replication.RequestVote:output_type -> VoteReply
+	28, // 51: Replication.Heartbeat:output_type -> HeartbeatReply
+	35, // 52: Replication.SyncState:output_type -> Snapshot
+	41, // [41:53] is the sub-list for method output_type
+	29, // [29:41] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_proto_proto_init() }
+func file_proto_proto_init() {
+	if File_proto_proto != nil {
+		return
+	}
+	file_proto_proto_msgTypes[13].OneofWrappers = []any{
+		(*Command_Bid)(nil),
+		(*Command_Create)(nil),
+		(*Command_Close)(nil),
+		(*Command_Drop)(nil),
+		(*Command_Accept)(nil),
+		(*Command_MaxBid)(nil),
+		(*Command_Register)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_proto_rawDesc), len(file_proto_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      2,
+			NumMessages:   35,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_proto_goTypes,
 		DependencyIndexes: file_proto_proto_depIdxs,
+		EnumInfos:         file_proto_proto_enumTypes,
 		MessageInfos:      file_proto_proto_msgTypes,
 	}.Build()
 	File_proto_proto = out.File
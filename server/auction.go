@@ -0,0 +1,727 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"log"
+	"math"
+	"time"
+)
+
+// auction modes, first-price and second-price (Vickrey) are sealed-bid.
+// in a dutch auction the asking price drops until someone accepts it
+const (
+	english     = "english"
+	firstPrice  = "first-price"
+	secondPrice = "second-price"
+	dutch       = "dutch"
+)
+
+type sealedBid struct {
+	bidder int32
+	amount int32
+}
+
+// a secret maximum the server bids up to for the bidder in an english auction
+type proxyBid struct {
+	bidder  int32
+	maximum int32
+}
+
+// how much a bid in an english auction must be above the highest bid, only one of fixed, percent and tiers is set
+type incrementRule struct {
+	fixed   int32
+	percent int32
+	tiers   []incrementTier // sorted by from
+}
+
+// a larger percentage would make the next bid more than ten times the highest bid
+const maxIncrementPercent = 1000
+
+type incrementTier struct {
+	from int32
+	step int32
+}
+
+func newIncrementRule(rule *proto.IncrementRule) incrementRule {
+	r := incrementRule{fixed: rule.GetFixed(), percent: rule.GetPercent()}
+	for _, tier := range rule.GetTiers() {
+		r.tiers = append(r.tiers, incrementTier{from: tier.From, step: tier.Step})
+	}
+	return r
+}
+
+func (r incrementRule) toProto() *proto.IncrementRule {
+	rule := &proto.IncrementRule{Fixed: r.fixed, Percent: r.percent}
+	for _, tier := range r.tiers {
+		rule.Tiers = append(rule.Tiers, &proto.IncrementTier{From: tier.from, Step: tier.step})
+	}
+	return rule
+}
+
+// the increment that applies when the highest bid is price, never less than 1 and never past math.MaxInt32
+func (r incrementRule) step(price int32) int32 {
+	var step int64
+	switch {
+	case len(r.tiers) > 0:
+		for _, tier := range r.tiers {
+			if tier.from <= price {
+				step = int64(tier.step)
+			}
+		}
+	case r.percent > 0:
+		step = (int64(price)*int64(r.percent) + 99) / 100
+	default:
+		step = int64(r.fixed)
+	}
+	return int32(min(max(step, 1), math.MaxInt32))
+}
+
+// the lowest amount that is an increment above price, math.MaxInt32 if that is further than an int32 goes
+func (r incrementRule) above(price int32) int32 {
+	return int32(min(int64(price)+int64(r.step(price)), math.MaxInt32))
+}
+
+// checked by the leader before the auction is created
+func validIncrement(rule *proto.IncrementRule) bool {
+	kinds := 0
+	if rule.GetFixed() != 0 {
+		kinds++
+	}
+	if rule.GetPercent() != 0 {
+		kinds++
+	}
+	if len(rule.GetTiers()) > 0 {
+		kinds++
+	}
+	if kinds > 1 || rule.GetFixed() < 0 || rule.GetPercent() < 0 || rule.GetPercent() > maxIncrementPercent {
+		return false
+	}
+	for i, tier := range rule.GetTiers() {
+		if tier.From < 0 || tier.Step <= 0 || (i > 0 && tier.From <= rule.Tiers[i-1].From) {
+			return false
+		}
+	}
+	return true
+}
+
+// holding replicated data of one auction
+type AuctionState struct {
+	id            int32
+	item          string
+	mode          string
+	startTime     time.Time
+	endTime       time.Time
+	auctionClosed bool // only set by a replicated close command, never by looking at the clock
+	closing       bool // the leader has proposed to close it, not replicated
+
+	//anti-sniping, a successful bid within extendWithin of the end moves the end time by extendBy
+	extendWithin time.Duration
+	extendBy     time.Duration
+
+	highestBid    int32 // in sealed-bid modes only set once the auction is closed
+	highestBidder int32
+	price         int32       // what the winner pays, set when the auction is closed. the asking price of an open dutch auction
+	sealedBids    []sealedBid // in the order they were committed, so ties go to the earliest bid
+
+	//dutch only, the asking price drops by priceStep every stepEvery until it reaches floorPrice
+	priceStep      int32
+	stepEvery      time.Duration
+	floorPrice     int32
+	priceDroppedAt time.Time
+	dropping       bool // the leader has proposed the next drop, not replicated
+
+	reservePrice int32 // hidden from clients, nothing is sold if the highest bid is below it
+	buyNowPrice  int32 // a bid of at least this amount wins at this price and closes the auction
+	increment    incrementRule
+	proxies      []proxyBid // secret maximum bids, in the order they were placed so ties go to the earliest
+
+	history  []bidRecord               // every attempt to buy the item in log order
+	requests map[requestKey]*proto.Ack // answers to the bids with a request id, so a retry is not applied twice
+}
+
+// reports whether a closed auction ended without a sale because its reserve was not met
+func (a *AuctionState) reserveNotMet() bool {
+	return a.auctionClosed && a.mode != dutch && a.highestBid < a.reservePrice
+}
+
+func (a *AuctionState) sealed() bool {
+	return a.mode == firstPrice || a.mode == secondPrice
+}
+
+// returns why the bid can not be accepted, or NO_REASON if it can. checked when it is applied
+func (a *AuctionState) checkBid(bidder int32, amount int32) proto.Reason {
+	if a.mode == dutch {
+		//the only way to win a dutch auction is to accept the asking price
+		return proto.Reason_WRONG_MODE
+	}
+	if !a.sealed() {
+		switch {
+		//the buy-it-now price always beats the highest bid, even if the increment would ask for more
+		case a.buyNowPrice > 0 && amount >= a.buyNowPrice && amount > a.highestBid:
+			return proto.Reason_NO_REASON
+		case amount <= a.highestBid:
+			return proto.Reason_BELOW_CURRENT
+		case amount < a.minBid():
+			return proto.Reason_INCREMENT_TOO_SMALL
+		}
+		return proto.Reason_NO_REASON
+	}
+	//any positive amount, but only once per bidder
+	if amount <= 0 {
+		return proto.Reason_BELOW_CURRENT
+	}
+	for _, bid := range a.sealedBids {
+		if bid.bidder == bidder {
+			return proto.Reason_ALREADY_BID
+		}
+	}
+	return proto.Reason_NO_REASON
+}
+
+// returns why a maximum bid can not be placed, or NO_REASON if it can. the highest bidder can raise their maximum
+// by any amount
+func (a *AuctionState) checkMaxBid(bidder int32, maximum int32) proto.Reason {
+	if a.mode != english {
+		return proto.Reason_WRONG_MODE
+	}
+	if bidder == a.highestBidder && a.highestBid > 0 {
+		for _, proxy := range a.proxies {
+			if proxy.bidder == bidder && maximum <= proxy.maximum {
+				return proto.Reason_BELOW_CURRENT
+			}
+		}
+		if maximum <= a.highestBid {
+			return proto.Reason_BELOW_CURRENT
+		}
+		return proto.Reason_NO_REASON
+	}
+	switch {
+	case maximum <= a.highestBid:
+		return proto.Reason_BELOW_CURRENT
+	case maximum < a.minBid():
+		return proto.Reason_INCREMENT_TOO_SMALL
+	}
+	return proto.Reason_NO_REASON
+}
+
+// replaces the maximum of the bidder, a raised maximum counts from when it was raised
+func (a *AuctionState) setProxy(bidder int32, maximum int32) {
+	for i, proxy := range a.proxies {
+		if proxy.bidder == bidder {
+			a.proxies = append(a.proxies[:i], a.proxies[i+1:]...)
+			break
+		}
+	}
+	a.proxies = append(a.proxies, proxyBid{bidder: bidder, maximum: maximum})
+}
+
+// lets the maximum bids answer the highest bid. the largest maximum wins, the earliest on ties, and bids one
+// increment above the best competing maximum or bid, but never above its own maximum. a maximum is always above
+// the highest bid when it is placed, so one that a bid has since reached was there first and keeps the lead.
+// returns whether the highest bid changed
+func (a *AuctionState) resolveProxies() bool {
+	if len(a.proxies) == 0 {
+		return false
+	}
+	top := a.proxies[0]
+	for _, proxy := range a.proxies[1:] {
+		if proxy.maximum > top.maximum {
+			top = proxy
+		}
+	}
+	var competing int32
+	for _, proxy := range a.proxies {
+		if proxy.bidder != top.bidder {
+			competing = max(competing, proxy.maximum)
+		}
+	}
+
+	var amount int32
+	if top.bidder == a.highestBidder && a.highestBid > 0 {
+		//the others bid up to their maximum, but none of them can get past the top
+		if competing < a.minBid() {
+			return false
+		}
+		amount = min(top.maximum, a.increment.above(competing))
+	} else {
+		if top.maximum < a.minBid() && (a.highestBid == 0 || top.maximum < a.highestBid) {
+			return false
+		}
+		competing = max(competing, a.highestBid)
+		raise := a.minBid()
+		if competing > 0 {
+			raise = max(raise, a.increment.above(competing))
+		}
+		amount = min(top.maximum, raise)
+	}
+	a.highestBid = amount
+	a.highestBidder = top.bidder
+	return true
+}
+
+// anti-sniping, a successful bid within extendWithin of the end moves the end time by extendBy
+func (a *AuctionState) extend(bidTime time.Time) {
+	if a.extendBy > 0 && !bidTime.Before(a.endTime.Add(-a.extendWithin)) {
+		a.endTime = a.endTime.Add(a.extendBy)
+		log.Printf("Auction %d was extended to %v by the late bid", a.id, a.endTime.Format(time.TimeOnly))
+	}
+}
+
+// the answer to a successful bid, telling the bidder if a maximum bid of someone else outbid them right away
+func (a *AuctionState) accepted(bidder int32) *proto.Ack {
+	ack := &proto.Ack{Outcome: proto.BidOutcome_SUCCESS, EndTime: a.endTime.UnixMilli()}
+	if a.mode == english && a.highestBidder != bidder {
+		ack.Outbid = true
+		ack.MinBid = a.minBid()
+	}
+	return ack
+}
+
+// the lowest amount the next bid of an english auction can have, the first bid only has to be positive
+func (a *AuctionState) minBid() int32 {
+	if a.highestBid == 0 {
+		return 1
+	}
+	return a.increment.above(a.highestBid)
+}
+
+// what a client is allowed to see, sealed bids stay hidden until the auction is closed
+func (a *AuctionState) outcome() *proto.Outcome {
+	outcome := &proto.Outcome{
+		ActionClosed: a.auctionClosed,
+		AuctionId:    a.id,
+		Item:         a.item,
+		StartTime:    a.startTime.UnixMilli(),
+		EndTime:      a.endTime.UnixMilli(),
+		Mode:         a.mode,
+		BuyNowPrice:  a.buyNowPrice,
+	}
+	if !a.sealed() || a.auctionClosed {
+		outcome.Id = a.highestBidder
+		outcome.HighestBid = a.highestBid
+		outcome.Price = a.price
+	}
+	outcome.ReserveNotMet = a.reserveNotMet()
+	if a.mode == english && !a.auctionClosed {
+		outcome.MinBid = a.minBid()
+	}
+	return outcome
+}
+
+// the answer to a bid that was not valid, telling the client why and what it would have to bid instead
+func (a *AuctionState) rejected(reason proto.Reason) *proto.Ack {
+	ack := &proto.Ack{Outcome: proto.BidOutcome_FAIL, Reason: reason, EndTime: a.endTime.UnixMilli()}
+	if a.mode == english {
+		ack.MinBid = a.minBid()
+	}
+	return ack
+}
+
+// applies a committed log entry to the auction state, called in log order on every server
+func (s *AuctionServer) apply(entry *proto.LogEntry) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updateLamportOnReceive(entry.Lamport)
+
+	id := s.commandAuction(entry.Command)
+	before := s.watchedOutcome(id)
+	var result any
+	switch command := entry.Command.GetKind().(type) {
+	case *proto.Command_Create:
+		result = s.applyCreate(command.Create)
+	case *proto.Command_Bid:
+		if ack := s.answered(command.Bid); ack != nil {
+			result = ack
+			break
+		}
+		ack := s.applyBid(command.Bid, time.UnixMilli(entry.Time))
+		s.record(command.Bid.AuctionId, "bid", command.Bid.Id, command.Bid.Amount, ack, entry)
+		s.remember(command.Bid, ack)
+		result = ack
+	case *proto.Command_Close:
+		result = s.applyClose(command.Close)
+	case *proto.Command_Drop:
+		result = s.applyDrop(command.Drop, time.UnixMilli(entry.Time))
+	case *proto.Command_Accept:
+		if ack := s.answered(command.Accept); ack != nil {
+			result = ack
+			break
+		}
+		ack := s.applyAccept(command.Accept, time.UnixMilli(entry.Time))
+		s.record(command.Accept.AuctionId, "accept", command.Accept.Id, ack.Price, ack, entry)
+		s.remember(command.Accept, ack)
+		result = ack
+	case *proto.Command_Register:
+		result = s.applyRegister(command.Register)
+	case *proto.Command_MaxBid:
+		if ack := s.answered(command.MaxBid); ack != nil {
+			result = ack
+			break
+		}
+		ack := s.applyMaxBid(command.MaxBid, time.UnixMilli(entry.Time))
+		s.record(command.MaxBid.AuctionId, "max", command.MaxBid.Id, command.MaxBid.Amount, ack, entry)
+		s.remember(command.MaxBid, ack)
+		result = ack
+	}
+	s.notify(id, before)
+	return result
+}
+
+// the leader has filled in the start and end time, so every server creates the same auction
+func (s *AuctionServer) applyCreate(in *proto.NewAuction) *proto.AuctionCreated {
+	auction := &AuctionState{
+		id:           s.nextAuctionId,
+		item:         in.Item,
+		mode:         in.Mode,
+		startTime:    time.UnixMilli(in.StartTime),
+		endTime:      time.UnixMilli(in.EndTime),
+		extendWithin: time.Duration(in.ExtendWithin) * time.Second,
+		extendBy:     time.Duration(in.ExtendBy) * time.Second,
+		reservePrice: in.ReservePrice,
+		buyNowPrice:  in.BuyNowPrice,
+		increment:    newIncrementRule(in.Increment),
+	}
+	if auction.mode == dutch {
+		auction.price = in.StartPrice
+		auction.priceStep = in.PriceStep
+		auction.stepEvery = time.Duration(in.StepEvery) * time.Second
+		auction.floorPrice = in.FloorPrice
+		auction.priceDroppedAt = auction.startTime
+	}
+	s.auctions[auction.id] = auction
+	s.nextAuctionId++
+	log.Printf("Auction %d of %q was created as %s auction, open from %v to %v (time=%d)", auction.id, auction.item, auction.mode, auction.startTime.Format(time.TimeOnly), auction.endTime.Format(time.TimeOnly), s.lamport)
+	return &proto.AuctionCreated{AuctionId: auction.id}
+}
+
+// bids that come after the close in the log are too late on every server
+func (s *AuctionServer) applyClose(in *proto.CloseAuction) any {
+	auction, ok := s.auctions[in.AuctionId]
+	if !ok || auction.auctionClosed {
+		return nil
+	}
+	if auction.endTime.UnixMilli() != in.EndTime {
+		// a bid extended the auction after the leader decided to close it
+		auction.closing = false
+		return nil
+	}
+	auction.auctionClosed = true
+	auction.closing = false
+	auction.settle()
+	if auction.reserveNotMet() {
+		log.Printf("Auction %d of %q closed without a sale as the highest bid %d did not meet the reserve (time=%d)", auction.id, auction.item, auction.highestBid, s.lamport)
+		return nil
+	}
+	log.Printf("Auction %d of %q closed with highest bid %d by %d at price %d (time=%d)", auction.id, auction.item, auction.highestBid, auction.highestBidder, auction.price, s.lamport)
+	return nil
+}
+
+// decides the winner and what they pay once the auction is closed
+func (a *AuctionState) settle() {
+	if a.mode == dutch {
+		//nobody accepted any of the asking prices
+		a.price = 0
+		return
+	}
+	if !a.sealed() {
+		a.price = a.highestBid
+		if a.highestBid < a.reservePrice {
+			a.price = 0
+		}
+		return
+	}
+
+	var second int32
+	for _, bid := range a.sealedBids {
+		if bid.amount > a.highestBid {
+			second = a.highestBid
+			a.highestBid = bid.amount
+			a.highestBidder = bid.bidder
+		} else if bid.amount > second {
+			second = bid.amount
+		}
+	}
+
+	a.price = a.highestBid
+	//in a Vickrey auction the winner pays the second highest bid, or their own if nobody else bid,
+	//but never less than the reserve
+	if a.mode == secondPrice && second > 0 {
+		a.price = max(second, a.reservePrice)
+	}
+	if a.highestBid < a.reservePrice {
+		a.price = 0
+	}
+}
+
+// the asking price of a dutch auction drops one step, acceptances before it in the log got the old price
+func (s *AuctionServer) applyDrop(in *proto.PriceDrop, dropTime time.Time) any {
+	auction, ok := s.auctions[in.AuctionId]
+	if !ok {
+		return nil
+	}
+	auction.dropping = false
+	if auction.auctionClosed || auction.price != in.From {
+		return nil
+	}
+	auction.price = in.To
+	auction.priceDroppedAt = dropTime
+	log.Printf("Asking price of auction %d dropped to %d (time=%d)", auction.id, auction.price, s.lamport)
+	return nil
+}
+
+// the first acceptance in the log wins a dutch auction, so a failover can never sell it twice
+func (s *AuctionServer) applyAccept(in *proto.Acceptance, acceptTime time.Time) *proto.Ack {
+	auction, ok := s.auctions[in.AuctionId]
+	if !ok || auction.auctionClosed || !acceptTime.Before(auction.endTime) {
+		log.Printf("Acceptance by %v caused exception as auction %d closed before it", in.Id, in.AuctionId)
+		return &proto.Ack{Outcome: proto.BidOutcome_EXCEPTION, Reason: proto.Reason_AUCTION_CLOSED}
+	}
+	if acceptTime.Before(auction.startTime) {
+		log.Printf("Acceptance by %v caused exception as auction %d has not started", in.Id, in.AuctionId)
+		return &proto.Ack{Outcome: proto.BidOutcome_EXCEPTION, Reason: proto.Reason_NOT_STARTED}
+	}
+	if auction.mode != dutch {
+		log.Printf("Acceptance by %v fail as auction %d is not a dutch auction", in.Id, in.AuctionId)
+		return auction.rejected(proto.Reason_WRONG_MODE)
+	}
+
+	auction.highestBid = auction.price
+	auction.highestBidder = in.Id
+	auction.auctionClosed = true
+	log.Printf("Auction %d of %q was sold to %d who accepted the price %d (time=%d)", auction.id, auction.item, in.Id, auction.price, s.lamport)
+	return &proto.Ack{Outcome: proto.BidOutcome_SUCCESS, EndTime: auction.endTime.UnixMilli(), Price: auction.price}
+}
+
+// bidTime is when the leader proposed the bid, which decides whether it was in time on every server
+func (s *AuctionServer) applyBid(in *proto.Amount, bidTime time.Time) *proto.Ack {
+	auction, ok := s.auctions[in.AuctionId]
+	if !ok || auction.auctionClosed || !bidTime.Before(auction.endTime) {
+		log.Printf("Bid by %v of %d caused exception as auction %d closed before it", in.Id, in.Amount, in.AuctionId)
+		return &proto.Ack{Outcome: proto.BidOutcome_EXCEPTION, Reason: proto.Reason_AUCTION_CLOSED}
+	}
+	if bidTime.Before(auction.startTime) {
+		log.Printf("Bid by %v of %d caused exception as auction %d has not started", in.Id, in.Amount, in.AuctionId)
+		return &proto.Ack{Outcome: proto.BidOutcome_EXCEPTION, Reason: proto.Reason_NOT_STARTED}
+	}
+
+	if reason := auction.checkBid(in.Id, in.Amount); reason != proto.Reason_NO_REASON {
+		log.Printf("Bid by %v of %d fail as it was not a valid bid: %v", in.Id, in.Amount, reason)
+		return auction.rejected(reason)
+	}
+
+	if auction.sealed() {
+		auction.sealedBids = append(auction.sealedBids, sealedBid{bidder: in.Id, amount: in.Amount})
+		log.Printf("Sealed bid by %v was successfully added to auction %d (time=%d)", in.Id, auction.id, s.lamport)
+	} else {
+		auction.highestBidder = in.Id
+		auction.highestBid = in.Amount
+		log.Printf("Bid by %v of %d was successfully added to auction %d (time=%d)", in.Id, in.Amount, auction.id, s.lamport)
+	}
+
+	//matching the buy-it-now price wins at that price straight away, the bids in the log after it are too late
+	if auction.buyNowPrice > 0 && in.Amount >= auction.buyNowPrice {
+		auction.highestBid = in.Amount
+		auction.highestBidder = in.Id
+		auction.price = auction.buyNowPrice
+		auction.auctionClosed = true
+		log.Printf("Auction %d of %q was bought by %d at the buy-it-now price %d (time=%d)", auction.id, auction.item, in.Id, auction.price, s.lamport)
+		return &proto.Ack{Outcome: proto.BidOutcome_SUCCESS, EndTime: auction.endTime.UnixMilli(), Price: auction.price}
+	}
+
+	if auction.mode == english && auction.resolveProxies() {
+		log.Printf("Maximum bid by %v raised the highest bid of auction %d to %d (time=%d)", auction.highestBidder, auction.id, auction.highestBid, s.lamport)
+	}
+	auction.extend(bidTime)
+	return auction.accepted(in.Id)
+}
+
+// stores the secret maximum of the bidder and bids as little as needed for them to lead, up to that maximum
+func (s *AuctionServer) applyMaxBid(in *proto.Amount, bidTime time.Time) *proto.Ack {
+	auction, ok := s.auctions[in.AuctionId]
+	if !ok || auction.auctionClosed || !bidTime.Before(auction.endTime) {
+		log.Printf("Maximum bid by %v of %d caused exception as auction %d closed before it", in.Id, in.Amount, in.AuctionId)
+		return &proto.Ack{Outcome: proto.BidOutcome_EXCEPTION, Reason: proto.Reason_AUCTION_CLOSED}
+	}
+	if bidTime.Before(auction.startTime) {
+		log.Printf("Maximum bid by %v of %d caused exception as auction %d has not started", in.Id, in.Amount, in.AuctionId)
+		return &proto.Ack{Outcome: proto.BidOutcome_EXCEPTION, Reason: proto.Reason_NOT_STARTED}
+	}
+	if reason := auction.checkMaxBid(in.Id, in.Amount); reason != proto.Reason_NO_REASON {
+		log.Printf("Maximum bid by %v of %d fail as it was not a valid maximum bid: %v", in.Id, in.Amount, reason)
+		return auction.rejected(reason)
+	}
+	//a maximum that reaches the buy-it-now price just buys the item
+	if auction.buyNowPrice > 0 && in.Amount >= auction.buyNowPrice {
+		return s.applyBid(in, bidTime)
+	}
+
+	auction.setProxy(in.Id, in.Amount)
+	log.Printf("Maximum bid by %v was successfully added to auction %d (time=%d)", in.Id, auction.id, s.lamport)
+	if auction.resolveProxies() {
+		log.Printf("Maximum bid by %v raised the highest bid of auction %d to %d (time=%d)", auction.highestBidder, auction.id, auction.highestBid, s.lamport)
+		auction.extend(bidTime)
+	}
+	return auction.accepted(in.Id)
+}
+
+// captures every auction, the registry and the lamport clock for a server that is catching up
+func (s *AuctionServer) snapshot() *proto.Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := &proto.Snapshot{
+		Lamport:       s.lamport,
+		NextAuctionId: s.nextAuctionId,
+		NextBidderId:  s.nextBidderId,
+	}
+	for _, b := range s.bidders {
+		snapshot.Bidders = append(snapshot.Bidders, &proto.BidderData{Id: b.id, Name: b.name, CredentialHash: b.credentialHash})
+	}
+	for _, auction := range s.auctions {
+		data := &proto.AuctionData{
+			Id:            auction.id,
+			Item:          auction.item,
+			Mode:          auction.mode,
+			StartTime:     auction.startTime.UnixMilli(),
+			EndTime:       auction.endTime.UnixMilli(),
+			ExtendWithin:  int32(auction.extendWithin / time.Second),
+			ExtendBy:      int32(auction.extendBy / time.Second),
+			Closed:        auction.auctionClosed,
+			HighestBid:    auction.highestBid,
+			HighestBidder: auction.highestBidder,
+			Price:         auction.price,
+			PriceStep:     auction.priceStep,
+			StepEvery:     int32(auction.stepEvery / time.Second),
+			FloorPrice:    auction.floorPrice,
+			ReservePrice:  auction.reservePrice,
+			BuyNowPrice:   auction.buyNowPrice,
+			Increment:     auction.increment.toProto(),
+		}
+		for _, proxy := range auction.proxies {
+			data.Proxies = append(data.Proxies, &proto.ProxyBid{Bidder: proxy.bidder, Maximum: proxy.maximum})
+		}
+		for _, record := range auction.history {
+			data.History = append(data.History, record.toProto())
+		}
+		for key, ack := range auction.requests {
+			data.Requests = append(data.Requests, &proto.AnsweredRequest{Bidder: key.bidder, RequestId: key.id, Ack: copyAck(ack)})
+		}
+		if auction.mode == dutch {
+			data.PriceDroppedAt = auction.priceDroppedAt.UnixMilli()
+		}
+		for _, bid := range auction.sealedBids {
+			data.SealedBids = append(data.SealedBids, &proto.SealedBid{Bidder: bid.bidder, Amount: bid.amount})
+		}
+		snapshot.Auctions = append(snapshot.Auctions, data)
+	}
+	return snapshot
+}
+
+// replaces every auction and the registry with the ones pulled from the leader
+func (s *AuctionServer) restore(snapshot *proto.Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auctions = make(map[int32]*AuctionState)
+	for _, data := range snapshot.Auctions {
+		auction := &AuctionState{
+			id:            data.Id,
+			item:          data.Item,
+			mode:          data.Mode,
+			startTime:     time.UnixMilli(data.StartTime),
+			endTime:       time.UnixMilli(data.EndTime),
+			extendWithin:  time.Duration(data.ExtendWithin) * time.Second,
+			extendBy:      time.Duration(data.ExtendBy) * time.Second,
+			auctionClosed: data.Closed,
+			highestBid:    data.HighestBid,
+			highestBidder: data.HighestBidder,
+			price:         data.Price,
+			priceStep:     data.PriceStep,
+			stepEvery:     time.Duration(data.StepEvery) * time.Second,
+			floorPrice:    data.FloorPrice,
+			reservePrice:  data.ReservePrice,
+			buyNowPrice:   data.BuyNowPrice,
+			increment:     newIncrementRule(data.Increment),
+		}
+		if auction.mode == dutch {
+			auction.priceDroppedAt = time.UnixMilli(data.PriceDroppedAt)
+		}
+		for _, bid := range data.SealedBids {
+			auction.sealedBids = append(auction.sealedBids, sealedBid{bidder: bid.Bidder, amount: bid.Amount})
+		}
+		for _, proxy := range data.Proxies {
+			auction.proxies = append(auction.proxies, proxyBid{bidder: proxy.Bidder, maximum: proxy.Maximum})
+		}
+		for _, record := range data.History {
+			auction.history = append(auction.history, newBidRecord(record))
+		}
+		for _, request := range data.Requests {
+			auction.remember(request.Bidder, request.RequestId, request.Ack)
+		}
+		s.auctions[data.Id] = auction
+	}
+	s.nextAuctionId = snapshot.NextAuctionId
+	s.bidders = make(map[int32]*bidder)
+	for _, data := range snapshot.Bidders {
+		s.bidders[data.Id] = &bidder{id: data.Id, name: data.Name, credentialHash: data.CredentialHash}
+	}
+	s.nextBidderId = snapshot.NextBidderId
+	s.updateLamportOnReceive(snapshot.Lamport)
+}
+
+// proposes to close every auction whose end time has passed and to drop the asking price of dutch auctions
+// when the next step is due, only does anything on the leader. both are replicated so the servers agree on
+// exactly which bids came in time and at which price
+func (s *AuctionServer) timerLoop() {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if !s.raft.isLeader() {
+			continue
+		}
+
+		s.mu.Lock()
+		lamport := s.lamport
+		for id, auction := range s.auctions {
+			if !auction.auctionClosed && !auction.closing && !now.Before(auction.endTime) {
+				auction.closing = true
+				go s.proposeClose(id, auction.endTime, lamport)
+			}
+			if auction.mode == dutch && !auction.auctionClosed && !auction.dropping && auction.price > auction.floorPrice &&
+				!now.Before(auction.priceDroppedAt.Add(auction.stepEvery)) {
+				auction.dropping = true
+				go s.proposeDrop(id, auction.price, max(auction.floorPrice, auction.price-auction.priceStep), lamport)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *AuctionServer) proposeClose(id int32, endTime time.Time, lamport int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	closeAuction := &proto.CloseAuction{AuctionId: id, EndTime: endTime.UnixMilli()}
+	_, err := s.raft.propose(ctx, &proto.Command{Kind: &proto.Command_Close{Close: closeAuction}}, lamport)
+	if err != nil {
+		log.Printf("Auction %d could not be closed yet: %v", id, err)
+		s.mu.Lock()
+		if auction, ok := s.auctions[id]; ok {
+			auction.closing = false
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *AuctionServer) proposeDrop(id int32, from int32, to int32, lamport int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	drop := &proto.PriceDrop{AuctionId: id, From: from, To: to}
+	_, err := s.raft.propose(ctx, &proto.Command{Kind: &proto.Command_Drop{Drop: drop}}, lamport)
+	if err != nil {
+		log.Printf("Asking price of auction %d could not be dropped yet: %v", id, err)
+		s.mu.Lock()
+		if auction, ok := s.auctions[id]; ok {
+			auction.dropping = false
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// how much the failure detector suspects a server of having crashed
+type suspicion int
+
+const (
+	alive suspicion = iota
+	suspected
+	dead
+)
+
+func (l suspicion) String() string {
+	switch l {
+	case alive:
+		return "alive"
+	case suspected:
+		return "suspected"
+	default:
+		return "dead"
+	}
+}
+
+// failure detector based on heartbeats. a server is suspected when nothing has been heard from it
+// for suspectAfter and considered dead after deadAfter
+type detector struct {
+	mu           sync.Mutex
+	suspectAfter time.Duration
+	deadAfter    time.Duration
+	started      time.Time
+	lastHeard    map[string]time.Time
+	levels       map[string]suspicion // last reported level, used to log changes
+}
+
+func newDetector(suspectAfter, deadAfter time.Duration) *detector {
+	return &detector{
+		suspectAfter: suspectAfter,
+		deadAfter:    deadAfter,
+		started:      time.Now(),
+		lastHeard:    make(map[string]time.Time),
+		levels:       make(map[string]suspicion),
+	}
+}
+
+// records a heartbeat from the server and returns the level it had before
+func (d *detector) heard(key string) suspicion {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	before := d.levelLocked(key)
+	d.lastHeard[key] = time.Now()
+	d.levels[key] = alive
+	if before != alive {
+		log.Printf("%v is alive again", key)
+	}
+	return before
+}
+
+// restarts the silence of the server without counting it as a heartbeat
+func (d *detector) reset(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lastHeard[key] = time.Now()
+	d.levels[key] = alive
+}
+
+// returns how long it has been since the server was heard from, servers never heard from count from the start
+func (d *detector) silence(key string) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.silenceLocked(key)
+}
+
+func (d *detector) silenceLocked(key string) time.Duration {
+	last, ok := d.lastHeard[key]
+	if !ok {
+		last = d.started
+	}
+	return time.Since(last)
+}
+
+func (d *detector) level(key string) suspicion {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	level := d.levelLocked(key)
+	if level != d.levels[key] {
+		log.Printf("%v is %v after %v without a heartbeat", key, level, d.silenceLocked(key).Round(time.Millisecond))
+		d.levels[key] = level
+	}
+	return level
+}
+
+func (d *detector) levelLocked(key string) suspicion {
+	silence := d.silenceLocked(key)
+	switch {
+	case silence >= d.deadAfter:
+		return dead
+	case silence >= d.suspectAfter:
+		return suspected
+	default:
+		return alive
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// one attempt to buy the item, kept so disputes can be resolved after the auction
+type bidRecord struct {
+	bidder  int32
+	amount  int32
+	kind    string // bid, max, proxy or accept
+	outcome proto.BidOutcome
+	reason  proto.Reason
+	lamport int32     // of the log entry, so it is the same on every server
+	time    time.Time // when the leader received it
+}
+
+func newBidRecord(record *proto.BidRecord) bidRecord {
+	return bidRecord{
+		bidder:  record.Bidder,
+		amount:  record.Amount,
+		kind:    record.Kind,
+		outcome: record.Outcome,
+		reason:  record.Reason,
+		lamport: record.Lamport,
+		time:    time.UnixMilli(record.Time),
+	}
+}
+
+func (r bidRecord) toProto() *proto.BidRecord {
+	return &proto.BidRecord{
+		Bidder:  r.bidder,
+		Amount:  r.amount,
+		Kind:    r.kind,
+		Outcome: r.outcome,
+		Reason:  r.reason,
+		Lamport: r.lamport,
+		Time:    r.time.UnixMilli(),
+	}
+}
+
+// adds an applied attempt to the history of the auction, together with the bid a maximum bid made in answer to it
+func (s *AuctionServer) record(auctionId int32, kind string, bidder int32, amount int32, ack *proto.Ack, entry *proto.LogEntry) {
+	auction, ok := s.auctions[auctionId]
+	if !ok {
+		return
+	}
+	record := bidRecord{
+		bidder:  bidder,
+		amount:  amount,
+		kind:    kind,
+		outcome: ack.Outcome,
+		reason:  ack.Reason,
+		lamport: entry.Lamport,
+		time:    time.UnixMilli(entry.Time),
+	}
+	auction.history = append(auction.history, record)
+
+	//every highest bid is in the history, one that is not was made by a maximum bid
+	if ack.Outcome != proto.BidOutcome_SUCCESS || auction.mode != english {
+		return
+	}
+	if last, ok := auction.lastBid(); ok && last.bidder == auction.highestBidder && last.amount == auction.highestBid {
+		return
+	}
+	record.bidder = auction.highestBidder
+	record.amount = auction.highestBid
+	record.kind = "proxy"
+	auction.history = append(auction.history, record)
+}
+
+// the latest successful bid in the history, the highest bid unless a maximum bid answered it
+func (a *AuctionState) lastBid() (bidRecord, bool) {
+	for i := len(a.history) - 1; i >= 0; i-- {
+		record := a.history[i]
+		if (record.kind == "bid" || record.kind == "proxy") && record.outcome == proto.BidOutcome_SUCCESS {
+			return record, true
+		}
+	}
+	return bidRecord{}, false
+}
+
+// returns a page of at most 100 records, any server can answer as the history is replicated.
+// sealed and maximum amounts are only shown once the auction is closed
+func (s *AuctionServer) History(ctx context.Context, in *proto.HistoryRequest) (*proto.HistoryPage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updateLamportOnReceive(in.Lamport)
+	auction, ok := s.auctions[in.AuctionId]
+	if !ok {
+		return nil, noSuchAuction(in.AuctionId)
+	}
+	if in.Offset < 0 || in.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset and limit can not be negative")
+	}
+	limit := min(in.Limit, 100)
+	if limit == 0 {
+		limit = 20
+	}
+
+	total := int32(len(auction.history))
+	start := min(in.Offset, total)
+	end := min(start+limit, total)
+	page := &proto.HistoryPage{Total: total}
+	for _, record := range auction.history[start:end] {
+		data := record.toProto()
+		if !auction.auctionClosed && (auction.sealed() || record.kind == "max") {
+			data.Amount = 0
+		}
+		page.Records = append(page.Records, data)
+	}
+	if end < total {
+		page.NextOffset = end
+	}
+	s.incrementLamport()
+	page.Lamport = s.lamport
+	return page, nil
+}
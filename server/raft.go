@@ -0,0 +1,675 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	raftRPCTimeout    = time.Second
+	maxEntriesPerCall = 100
+)
+
+// the key the followers use in the failure detector for whoever is leader
+const leaderKey = "leader"
+
+var (
+	errNotLeader = errors.New("not leader")
+	// returned to a waiting caller when its command was overwritten by another leader
+	errLostLeadership = errors.New("leadership was lost before the command was committed")
+)
+
+// one of the other servers as seen from this one
+type peer struct {
+	address    string
+	client     proto.ReplicationClient
+	nextIndex  int32         // index of the next entry to send, only used by the leader
+	matchIndex int32         // highest index known to be replicated on the peer, only used by the leader
+	commitSent int32         // highest commit index sent to the peer, only used by the leader
+	trigger    chan struct{} // wakes up the replication loop when new entries are appended
+}
+
+// a waiting caller of propose
+type waiter struct {
+	term   int32
+	result chan any
+}
+
+// raft keeps the log of auction commands consistent between the servers.
+// it elects one leader per term and only reports a command as committed once a quorum of servers holds it
+type raft struct {
+	mu sync.Mutex
+
+	id      int32
+	address string // the address the other servers know this one by
+	peers   []*peer
+	quorum  int
+
+	role          string // follower, candidate or leader
+	currentTerm   int32
+	votedFor      int32  // -1 if no vote has been cast in the current term
+	leaderId      int32  // -1 if the leader is unknown
+	leaderAddress string // empty if the leader is unknown
+
+	log         []*proto.LogEntry // log[0] is the last entry covered by a snapshot, or a sentinel before the first entry
+	offset      int32             // index of log[0]
+	commitIndex int32
+	lastApplied int32
+	wal         *wal // the changes to the above are written to it first, nil without -data-dir
+
+	detector  *detector     // tracks the peers when leader and the leader when follower
+	heartbeat time.Duration // how often the leader sends heartbeats
+	jitter    time.Duration // random extra wait before taking over, so the followers do not all start an election at once
+	applyCond *sync.Cond
+	applyMu   sync.Mutex // held while the auction state is changed, taken before mu
+	waiters   map[int32]waiter
+	syncNow   chan struct{} // wakes up the sync loop when this server is behind the leader
+
+	apply    func(entry *proto.LogEntry) any // applies a committed entry to the auction state
+	snapshot func() *proto.Snapshot          // captures the auction state, called with applyMu held
+	restore  func(snapshot *proto.Snapshot)  // replaces the auction state, called with applyMu held
+}
+
+func newRaft(id int32, address string, peers []*peer, quorum int, heartbeat time.Duration, detector *detector) *raft {
+	r := &raft{
+		id:        id,
+		address:   address,
+		peers:     peers,
+		quorum:    quorum,
+		role:      "follower",
+		votedFor:  -1,
+		leaderId:  -1,
+		log:       []*proto.LogEntry{{Term: 0}},
+		detector:  detector,
+		heartbeat: heartbeat,
+		waiters:   make(map[int32]waiter),
+		syncNow:   make(chan struct{}, 1),
+	}
+	r.applyCond = sync.NewCond(&r.mu)
+	r.resetElectionTimer(false)
+	return r
+}
+
+// starts the background loops, must be called after apply, snapshot and restore are set
+func (r *raft) start() {
+	go r.electionLoop()
+	go r.heartbeatLoop()
+	go r.applyLoop()
+	go r.syncLoop()
+	for _, p := range r.peers {
+		go r.replicationLoop(p)
+	}
+}
+
+func (r *raft) lastIndex() int32 {
+	return r.offset + int32(len(r.log)-1)
+}
+
+func (r *raft) entry(index int32) *proto.LogEntry {
+	return r.log[index-r.offset]
+}
+
+func (r *raft) termAt(index int32) int32 {
+	return r.entry(index).Term
+}
+
+func (r *raft) isLeader() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.role == "leader"
+}
+
+// the address of the leader as far as this server knows, empty if it does not know one
+func (r *raft) leader() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.leaderAddress
+}
+
+// appends a command to the log if this server is the leader, blocks until it has been applied
+// and returns what apply returned for it
+func (r *raft) propose(ctx context.Context, command *proto.Command, lamport int32) (any, error) {
+	r.mu.Lock()
+	if r.role != "leader" {
+		r.mu.Unlock()
+		return nil, errNotLeader
+	}
+	entry := &proto.LogEntry{Term: r.currentTerm, Lamport: lamport, Command: command, Time: time.Now().UnixMilli()}
+	r.log = append(r.log, entry)
+	index := r.lastIndex()
+	r.persist(index, []*proto.LogEntry{entry}, nil)
+	w := waiter{term: r.currentTerm, result: make(chan any, 1)}
+	r.waiters[index] = w
+	r.triggerReplication()
+	r.advanceCommitIndex()
+	r.mu.Unlock()
+
+	select {
+	case result := <-w.result:
+		if err, failed := result.(error); failed {
+			return nil, err
+		}
+		return result, nil
+	case <-ctx.Done():
+		r.mu.Lock()
+		delete(r.waiters, index)
+		r.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (r *raft) triggerReplication() {
+	for _, p := range r.peers {
+		select {
+		case p.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// postpones taking over, called on contact with a legitimate leader or candidate and when starting an election
+func (r *raft) resetElectionTimer(fromLeader bool) {
+	if fromLeader {
+		r.detector.heard(leaderKey)
+	} else {
+		r.detector.reset(leaderKey)
+	}
+	r.jitter = time.Duration(rand.Int63n(int64(r.detector.deadAfter/2) + 1))
+}
+
+// moves to a newer term as follower, must be called with the lock held
+func (r *raft) stepDown(term int32) {
+	if term > r.currentTerm {
+		r.currentTerm = term
+		r.votedFor = -1
+		r.persist(0, nil, nil)
+	}
+	if r.role != "follower" {
+		log.Printf("Stepped down to follower in term %d", r.currentTerm)
+	}
+	r.role = "follower"
+}
+
+// takes over by starting an election once the failure detector considers the leader dead
+func (r *raft) electionLoop() {
+	ticker := time.NewTicker(r.heartbeat / 3)
+	defer ticker.Stop()
+	for range ticker.C {
+		r.mu.Lock()
+		if r.role == "leader" {
+			r.mu.Unlock()
+			continue
+		}
+		timedOut := r.detector.level(leaderKey) == dead && r.detector.silence(leaderKey) > r.detector.deadAfter+r.jitter
+		r.mu.Unlock()
+		if timedOut {
+			r.startElection()
+		}
+	}
+}
+
+// sends heartbeats to every peer while leader. a peer that answers again after being considered dead
+// is re-admitted to the replication
+func (r *raft) heartbeatLoop() {
+	ticker := time.NewTicker(r.heartbeat)
+	defer ticker.Stop()
+	for range ticker.C {
+		r.mu.Lock()
+		if r.role != "leader" {
+			r.mu.Unlock()
+			continue
+		}
+		req := &proto.HeartbeatRequest{Term: r.currentTerm, LeaderId: r.id, LeaderAddress: r.address}
+		r.mu.Unlock()
+
+		for _, p := range r.peers {
+			go func(p *peer) {
+				ctx, cancel := context.WithTimeout(context.Background(), r.heartbeat)
+				defer cancel()
+				reply, err := p.client.Heartbeat(ctx, req)
+				if err != nil {
+					return
+				}
+
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				if reply.Term > r.currentTerm {
+					r.stepDown(reply.Term)
+					return
+				}
+				if r.detector.heard(p.address) == dead && r.role == "leader" {
+					log.Printf("Re-admitted peer %v to the replication", p.address)
+					p.nextIndex = r.lastIndex() + 1
+					r.triggerPeer(p)
+				}
+			}(p)
+		}
+	}
+}
+
+func (r *raft) startElection() {
+	r.mu.Lock()
+	r.role = "candidate"
+	r.currentTerm++
+	r.votedFor = r.id
+	r.leaderId = -1
+	r.leaderAddress = ""
+	r.resetElectionTimer(false)
+	r.persist(0, nil, nil)
+	term := r.currentTerm
+	req := &proto.VoteRequest{
+		Term:         term,
+		CandidateId:  r.id,
+		LastLogIndex: r.lastIndex(),
+		LastLogTerm:  r.termAt(r.lastIndex()),
+	}
+	r.mu.Unlock()
+	log.Printf("Starting election for term %d", term)
+
+	votes := 1
+	if votes >= r.quorum {
+		r.becomeLeader(term)
+		return
+	}
+	for _, p := range r.peers {
+		go func(p *peer) {
+			ctx, cancel := context.WithTimeout(context.Background(), raftRPCTimeout)
+			defer cancel()
+			reply, err := p.client.RequestVote(ctx, req)
+			if err != nil {
+				return
+			}
+			r.detector.heard(p.address)
+
+			r.mu.Lock()
+			if reply.Term > r.currentTerm {
+				r.stepDown(reply.Term)
+				r.mu.Unlock()
+				return
+			}
+			if !reply.VoteGranted || r.role != "candidate" || r.currentTerm != term {
+				r.mu.Unlock()
+				return
+			}
+			votes++
+			won := votes == r.quorum
+			r.mu.Unlock()
+			if won {
+				r.becomeLeader(term)
+			}
+		}(p)
+	}
+}
+
+func (r *raft) becomeLeader(term int32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.role != "candidate" || r.currentTerm != term {
+		return
+	}
+	r.role = "leader"
+	r.leaderId = r.id
+	r.leaderAddress = r.address
+	for _, p := range r.peers {
+		p.nextIndex = r.lastIndex() + 1
+		p.matchIndex = 0
+		p.commitSent = 0
+	}
+	// entries from earlier terms can only be committed together with one from the current term
+	r.log = append(r.log, &proto.LogEntry{Term: term, Command: &proto.Command{}})
+	r.persist(r.lastIndex(), r.log[len(r.log)-1:], nil)
+	log.Printf("Became leader for term %d", term)
+	r.triggerReplication()
+	r.advanceCommitIndex()
+}
+
+// sends new entries and commit indexes to the peer whenever there are any, peers the failure detector
+// considers dead are skipped until they answer a heartbeat again
+func (r *raft) replicationLoop(p *peer) {
+	ticker := time.NewTicker(r.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.trigger:
+		}
+
+		r.mu.Lock()
+		if r.role != "leader" || r.detector.level(p.address) == dead {
+			r.mu.Unlock()
+			continue
+		}
+		if p.nextIndex > r.lastIndex() && p.commitSent >= r.commitIndex {
+			r.mu.Unlock()
+			continue
+		}
+		term := r.currentTerm
+		prev := p.nextIndex - 1
+		if prev < r.offset {
+			// the entries the peer needs are only in a snapshot. appending after it makes the peer
+			// notice that it is behind, so it pulls the snapshot with SyncState
+			prev = r.offset
+		}
+		end := min(r.lastIndex(), prev+maxEntriesPerCall)
+		req := &proto.AppendEntriesRequest{
+			Term:          term,
+			LeaderId:      r.id,
+			PrevLogIndex:  prev,
+			PrevLogTerm:   r.termAt(prev),
+			Entries:       append([]*proto.LogEntry(nil), r.log[prev+1-r.offset:end+1-r.offset]...),
+			LeaderCommit:  r.commitIndex,
+			LeaderAddress: r.address,
+		}
+		r.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), raftRPCTimeout)
+		reply, err := p.client.AppendEntries(ctx, req)
+		cancel()
+		if err != nil {
+			continue
+		}
+		r.detector.heard(p.address)
+
+		r.mu.Lock()
+		switch {
+		case reply.Term > r.currentTerm:
+			r.stepDown(reply.Term)
+		case r.role != "leader" || r.currentTerm != term:
+			// the reply belongs to an old term
+		case reply.Success:
+			p.commitSent = max(p.commitSent, req.LeaderCommit)
+			p.matchIndex = max(p.matchIndex, prev+int32(len(req.Entries)))
+			p.nextIndex = p.matchIndex + 1
+			r.advanceCommitIndex()
+			if p.nextIndex <= r.lastIndex() {
+				r.triggerPeer(p)
+			}
+		default:
+			p.nextIndex = max(1, min(reply.LastLogIndex+1, p.nextIndex-1))
+			r.triggerPeer(p)
+		}
+		r.mu.Unlock()
+	}
+}
+
+func (r *raft) triggerPeer(p *peer) {
+	select {
+	case p.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// commits the highest entry of the current term that a quorum holds, must be called with the lock held
+func (r *raft) advanceCommitIndex() {
+	for n := r.lastIndex(); n > r.commitIndex && r.termAt(n) == r.currentTerm; n-- {
+		count := 1
+		for _, p := range r.peers {
+			if p.matchIndex >= n {
+				count++
+			}
+		}
+		if count >= r.quorum {
+			r.commitIndex = n
+			r.persist(0, nil, nil)
+			r.applyCond.Broadcast()
+			r.triggerReplication()
+			return
+		}
+	}
+}
+
+// applies committed entries in order and hands the result to the waiting caller, if any
+func (r *raft) applyLoop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for {
+		for r.lastApplied >= r.commitIndex {
+			r.applyCond.Wait()
+		}
+
+		r.mu.Unlock()
+		r.applyMu.Lock()
+		r.mu.Lock()
+		if r.lastApplied >= r.commitIndex {
+			// a snapshot was installed in the meantime
+			r.applyMu.Unlock()
+			continue
+		}
+		r.lastApplied++
+		index := r.lastApplied
+		entry := r.entry(index)
+
+		r.mu.Unlock()
+		result := r.apply(entry)
+		r.applyMu.Unlock()
+		r.mu.Lock()
+
+		if w, ok := r.waiters[index]; ok {
+			delete(r.waiters, index)
+			if w.term != entry.Term {
+				result = errLostLeadership
+			}
+			w.result <- result
+		}
+	}
+}
+
+func (r *raft) handleAppendEntries(in *proto.AppendEntriesRequest) *proto.AppendEntriesReply {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if in.Term < r.currentTerm {
+		return &proto.AppendEntriesReply{Term: r.currentTerm, Success: false, LastLogIndex: r.lastIndex()}
+	}
+	if in.Term > r.currentTerm || r.role != "follower" {
+		r.stepDown(in.Term)
+	}
+	r.follow(in.LeaderId, in.LeaderAddress, in.Term)
+
+	//entries already covered by the snapshot are committed and do not have to be checked
+	if in.PrevLogIndex < r.offset {
+		skip := min(r.offset-in.PrevLogIndex, int32(len(in.Entries)))
+		in.Entries = in.Entries[skip:]
+		in.PrevLogIndex = r.offset
+		in.PrevLogTerm = r.termAt(r.offset)
+	}
+
+	//the entry before the new ones must match, otherwise the leader has to go further back
+	if in.PrevLogIndex > r.lastIndex() {
+		if in.PrevLogIndex <= in.LeaderCommit {
+			// missing committed entries, pulling the state is quicker than replaying them
+			r.requestSync()
+		}
+		return &proto.AppendEntriesReply{Term: r.currentTerm, Success: false, LastLogIndex: r.lastIndex()}
+	}
+	if r.termAt(in.PrevLogIndex) != in.PrevLogTerm {
+		return &proto.AppendEntriesReply{Term: r.currentTerm, Success: false, LastLogIndex: in.PrevLogIndex - 1}
+	}
+
+	var from int32 // index of the first entry that was added, 0 if they were all there already
+	for i, entry := range in.Entries {
+		index := in.PrevLogIndex + 1 + int32(i)
+		if index <= r.lastIndex() {
+			if r.termAt(index) == entry.Term {
+				continue
+			}
+			// conflicting entry, drop it and everything after it
+			r.log = r.log[:index-r.offset]
+		}
+		r.log = append(r.log, in.Entries[i:]...)
+		from = index
+		break
+	}
+
+	lastNew := in.PrevLogIndex + int32(len(in.Entries))
+	committed := in.LeaderCommit > r.commitIndex && lastNew > r.commitIndex
+	if committed {
+		r.commitIndex = min(in.LeaderCommit, lastNew)
+	}
+	if from > 0 {
+		r.persist(from, r.log[from-r.offset:], nil)
+	} else if committed {
+		r.persist(0, nil, nil)
+	}
+	//only applied once it is on disk, so a restart never applies less than before
+	if committed {
+		r.applyCond.Broadcast()
+	}
+	return &proto.AppendEntriesReply{Term: r.currentTerm, Success: true, LastLogIndex: r.lastIndex()}
+}
+
+func (r *raft) handleHeartbeat(in *proto.HeartbeatRequest) *proto.HeartbeatReply {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if in.Term < r.currentTerm {
+		return &proto.HeartbeatReply{Term: r.currentTerm, Success: false}
+	}
+	if in.Term > r.currentTerm || r.role != "follower" {
+		r.stepDown(in.Term)
+	}
+	r.follow(in.LeaderId, in.LeaderAddress, in.Term)
+	return &proto.HeartbeatReply{Term: r.currentTerm, Success: true}
+}
+
+// records the leader of the current term, must be called with the lock held
+func (r *raft) follow(leaderId int32, leaderAddress string, term int32) {
+	if r.leaderId != leaderId {
+		log.Printf("Following leader %d in term %d", leaderId, term)
+	}
+	r.leaderId = leaderId
+	r.leaderAddress = leaderAddress
+	r.resetElectionTimer(true)
+}
+
+func (r *raft) handleRequestVote(in *proto.VoteRequest) *proto.VoteReply {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if in.Term < r.currentTerm {
+		return &proto.VoteReply{Term: r.currentTerm, VoteGranted: false}
+	}
+	if in.Term > r.currentTerm {
+		r.stepDown(in.Term)
+		r.leaderId = -1
+		r.leaderAddress = ""
+	}
+
+	//only vote for candidates whose log is at least as up to date as ours
+	lastTerm := r.termAt(r.lastIndex())
+	upToDate := in.LastLogTerm > lastTerm || (in.LastLogTerm == lastTerm && in.LastLogIndex >= r.lastIndex())
+	if (r.votedFor == -1 || r.votedFor == in.CandidateId) && upToDate {
+		r.votedFor = in.CandidateId
+		r.persist(0, nil, nil)
+		r.resetElectionTimer(false)
+		log.Printf("Voted for %d in term %d", in.CandidateId, in.Term)
+		return &proto.VoteReply{Term: r.currentTerm, VoteGranted: true}
+	}
+	return &proto.VoteReply{Term: r.currentTerm, VoteGranted: false}
+}
+
+func (r *raft) requestSync() {
+	select {
+	case r.syncNow <- struct{}{}:
+	default:
+	}
+}
+
+// pulls the state from the leader when this server (re)joins the cluster and whenever it falls behind
+func (r *raft) syncLoop() {
+	r.requestSync()
+	for range r.syncNow {
+		for !r.syncFromLeader() {
+			time.Sleep(r.heartbeat)
+		}
+	}
+}
+
+// returns false if the state could not be pulled yet and should be tried again
+func (r *raft) syncFromLeader() bool {
+	r.mu.Lock()
+	if r.role == "leader" {
+		r.mu.Unlock()
+		return true
+	}
+	var leader *peer
+	for _, p := range r.peers {
+		if p.address == r.leaderAddress {
+			leader = p
+		}
+	}
+	r.mu.Unlock()
+	if leader == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), raftRPCTimeout)
+	defer cancel()
+	snapshot, err := leader.client.SyncState(ctx, &proto.SyncRequest{Id: r.id, Address: r.address})
+	if err != nil {
+		log.Printf("Could not sync state from %v: %v", leader.address, err)
+		return false
+	}
+	r.installSnapshot(snapshot)
+	return true
+}
+
+// replaces the state with the snapshot unless this server is already further ahead
+func (r *raft) installSnapshot(snapshot *proto.Snapshot) {
+	r.applyMu.Lock()
+	defer r.applyMu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if snapshot.LastIndex <= r.lastApplied {
+		return
+	}
+	r.replaceLog(snapshot)
+	r.persist(0, nil, snapshot)
+	r.lastApplied = snapshot.LastIndex
+	r.restore(snapshot)
+	log.Printf("Synced state up to entry %d from the leader", snapshot.LastIndex)
+}
+
+// replaces the log up to the end of the snapshot, must be called with the lock held
+func (r *raft) replaceLog(snapshot *proto.Snapshot) {
+	if snapshot.LastIndex <= r.lastIndex() && r.termAt(snapshot.LastIndex) == snapshot.LastTerm {
+		// keep the entries that come after the snapshot
+		r.log = r.log[snapshot.LastIndex-r.offset:]
+	} else {
+		r.log = []*proto.LogEntry{{Term: snapshot.LastTerm}}
+	}
+	r.offset = snapshot.LastIndex
+	r.commitIndex = max(r.commitIndex, snapshot.LastIndex)
+}
+
+// hands the applied state to a server that is (re)joining and re-admits it to the replication
+func (r *raft) handleSyncState(in *proto.SyncRequest) (*proto.Snapshot, error) {
+	r.applyMu.Lock()
+	defer r.applyMu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.role != "leader" {
+		return nil, errNotLeader
+	}
+	snapshot := r.snapshot()
+	snapshot.LastIndex = r.lastApplied
+	snapshot.LastTerm = r.termAt(r.lastApplied)
+
+	for _, p := range r.peers {
+		if p.address == in.Address {
+			p.matchIndex = max(p.matchIndex, r.lastApplied)
+			p.nextIndex = r.lastApplied + 1
+			r.detector.heard(p.address)
+			r.triggerPeer(p)
+			log.Printf("Server %d synced state up to entry %d and was re-added to the replication", in.Id, r.lastApplied)
+		}
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// a registered bidder, only the hash of the credential is kept
+type bidder struct {
+	id             int32
+	name           string
+	credentialHash []byte
+}
+
+// issues a bidder id and a credential, the id is given when the command is applied so every server agrees on it
+func (s *AuctionServer) Register(ctx context.Context, in *proto.Registration) (*proto.Registered, error) {
+	if !s.raft.isLeader() {
+		return nil, notLeader(s.raft.leader())
+	}
+
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("could not make a credential: %v", err)
+	}
+	credential := hex.EncodeToString(secret)
+	hash := sha256.Sum256([]byte(credential))
+
+	s.mu.Lock()
+	s.updateLamportOnReceive(in.Lamport)
+	lamport := s.lamport
+	s.mu.Unlock()
+
+	newBidder := &proto.NewBidder{Name: in.Name, CredentialHash: hash[:]}
+	result, err := s.raft.propose(ctx, &proto.Command{Kind: &proto.Command_Register{Register: newBidder}}, lamport)
+	if err != nil {
+		log.Printf("Bidder %q could not be registered: %v", in.Name, err)
+		return nil, notReplicated(err, s.raft.leader())
+	}
+
+	registered := result.(*proto.Registered)
+	registered.Credential = credential
+	s.mu.Lock()
+	s.incrementLamport()
+	registered.Lamport = s.lamport
+	s.mu.Unlock()
+	return registered, nil
+}
+
+func (s *AuctionServer) applyRegister(in *proto.NewBidder) *proto.Registered {
+	b := &bidder{id: s.nextBidderId, name: in.Name, credentialHash: in.CredentialHash}
+	s.bidders[b.id] = b
+	s.nextBidderId++
+	log.Printf("Bidder %v (%q) is registered and can now bid (time=%d)", b.id, b.name, s.lamport)
+	return &proto.Registered{Id: b.id}
+}
+
+// checks that the request comes from the registered bidder with the id, by the credential in the metadata.
+// called with s.mu held
+func (s *AuctionServer) authenticate(ctx context.Context, id int32) error {
+	b, ok := s.bidders[id]
+	if !ok {
+		return reject(codes.Unauthenticated, &proto.Rejection{Reason: proto.Reason_NOT_REGISTERED}, fmt.Sprintf("bidder %d is not registered", id))
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	credentials := md.Get("credential")
+	if len(credentials) != 1 || credentials[0] == "" {
+		return reject(codes.Unauthenticated, &proto.Rejection{Reason: proto.Reason_WRONG_CREDENTIAL}, "no credential was sent")
+	}
+	hash := sha256.Sum256([]byte(credentials[0]))
+	if subtle.ConstantTimeCompare(hash[:], b.credentialHash) != 1 {
+		return reject(codes.PermissionDenied, &proto.Rejection{Reason: proto.Reason_WRONG_CREDENTIAL}, fmt.Sprintf("the credential is not the one of bidder %d", id))
+	}
+	return nil
+}
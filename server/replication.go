@@ -0,0 +1,99 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// the internal service the servers replicate with, separate from the Auction service the bidders use.
+// only the members of the cluster may call it, proven by a certificate from the cluster CA and/or the cluster key.
+// that proves a caller is one of the servers, not which one, so the ids and addresses in the requests are trusted
+// like in raft itself
+type replicationServer struct {
+	proto.UnimplementedReplicationServer
+
+	raft       *raft
+	cluster    *x509.CertPool // CA of the member certificates, nil without -mtls
+	clusterKey string         // empty without -clusterKey
+}
+
+func newReplicationServer(r *raft, cfg Config) (*replicationServer, error) {
+	rs := &replicationServer{raft: r, clusterKey: cfg.ClusterKey}
+	if cfg.MTLS {
+		cluster, err := loadPool(cfg.CA)
+		if err != nil {
+			return nil, err
+		}
+		rs.cluster = cluster
+	}
+	return rs, nil
+}
+
+// sends the cluster key as metadata with every call to another server
+type clusterKey string
+
+func (k clusterKey) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"cluster-key": string(k)}, nil
+}
+
+// the key must not be readable on the wire
+func (k clusterKey) RequireTransportSecurity() bool {
+	return true
+}
+
+// checks that the caller is a member of the cluster
+func (rs *replicationServer) authenticate(ctx context.Context) error {
+	if rs.cluster != nil {
+		if err := clusterMember(ctx, rs.cluster); err != nil {
+			return status.Errorf(codes.PermissionDenied, "replication is only for the servers of the cluster: %v", err)
+		}
+	}
+	if rs.clusterKey != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get("cluster-key")
+		if len(keys) != 1 || subtle.ConstantTimeCompare([]byte(keys[0]), []byte(rs.clusterKey)) != 1 {
+			return status.Error(codes.Unauthenticated, "replication is only for the servers of the cluster: wrong cluster key")
+		}
+	}
+	return nil
+}
+
+// raft messages do not move the lamport clock, only the entries they carry do when they are applied
+func (rs *replicationServer) AppendEntries(ctx context.Context, in *proto.AppendEntriesRequest) (*proto.AppendEntriesReply, error) {
+	if err := rs.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return rs.raft.handleAppendEntries(in), nil
+}
+
+func (rs *replicationServer) RequestVote(ctx context.Context, in *proto.VoteRequest) (*proto.VoteReply, error) {
+	if err := rs.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return rs.raft.handleRequestVote(in), nil
+}
+
+func (rs *replicationServer) Heartbeat(ctx context.Context, in *proto.HeartbeatRequest) (*proto.HeartbeatReply, error) {
+	if err := rs.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return rs.raft.handleHeartbeat(in), nil
+}
+
+// hands the full auction state to a server that is (re)joining the cluster, only answered by the leader
+func (rs *replicationServer) SyncState(ctx context.Context, in *proto.SyncRequest) (*proto.Snapshot, error) {
+	if err := rs.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	snapshot, err := rs.raft.handleSyncState(in)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// serves the Replication service of a raft that is not started with the options of cfg, and returns its address
+func serveReplication(t *testing.T, cfg Config) string {
+	t.Helper()
+	options, err := serverOptions(cfg)
+	if err != nil {
+		t.Fatalf("server options: %v", err)
+	}
+	r := newRaft(1, "localhost:0", nil, 1, 50*time.Millisecond, newDetector(150*time.Millisecond, 250*time.Millisecond))
+	replication, err := newReplicationServer(r, cfg)
+	if err != nil {
+		t.Fatalf("replication server: %v", err)
+	}
+	server := grpc.NewServer(options...)
+	proto.RegisterReplicationServer(server, replication)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return "localhost:" + port
+}
+
+// sends a heartbeat like another server would, with the cluster key if it is not empty, and returns the code
+func heartbeat(t *testing.T, address string, creds credentials.TransportCredentials, key string) codes.Code {
+	t.Helper()
+	options := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if key != "" {
+		options = append(options, grpc.WithPerRPCCredentials(clusterKey(key)))
+	}
+	conn, err := grpc.NewClient(address, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = proto.NewReplicationClient(conn).Heartbeat(ctx, &proto.HeartbeatRequest{Term: 1, LeaderId: 2, LeaderAddress: "localhost:1"})
+	return status.Code(err)
+}
+
+func TestReplicationNeedsTheClusterKey(t *testing.T) {
+	c := newTestCerts(t)
+	cfg := Config{Cert: c.serverCert, Key: c.serverKey, CA: c.clusterCA.CertFile, ClusterKey: "secret"}
+	address := serveReplication(t, cfg)
+	peerCreds, err := peerCredentials(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := heartbeat(t, address, peerCreds, "secret"); code != codes.OK {
+		t.Errorf("a server with the cluster key got %v", code)
+	}
+	if code := heartbeat(t, address, peerCreds, "wrong"); code != codes.Unauthenticated {
+		t.Errorf("a caller with the wrong cluster key got %v, want %v", code, codes.Unauthenticated)
+	}
+	if code := heartbeat(t, address, peerCreds, ""); code != codes.Unauthenticated {
+		t.Errorf("a caller without a cluster key got %v, want %v", code, codes.Unauthenticated)
+	}
+}
+
+func TestReplicationWithMTLS(t *testing.T) {
+	c := newTestCerts(t)
+	cfg := Config{Cert: c.serverCert, Key: c.serverKey, CA: c.clusterCA.CertFile, MTLS: true}
+	address := serveReplication(t, cfg)
+	peerCreds, err := peerCredentials(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := heartbeat(t, address, peerCreds, ""); code != codes.OK {
+		t.Errorf("a server with a certificate of the cluster got %v", code)
+	}
+	if code := heartbeat(t, address, bidderCredentials(t, c, "", ""), ""); code != codes.PermissionDenied {
+		t.Errorf("a caller without a certificate got %v, want %v", code, codes.PermissionDenied)
+	}
+
+	//with -clientCA the certificate of a bidder gets through the handshake, but is not one of the cluster
+	cfg.ClientCA = c.bidderCA.CertFile
+	address = serveReplication(t, cfg)
+	if code := heartbeat(t, address, bidderCredentials(t, c, c.bidderCert, c.bidderKey), ""); code != codes.PermissionDenied {
+		t.Errorf("a bidder with a certificate got %v, want %v", code, codes.PermissionDenied)
+	}
+	if code := heartbeat(t, address, peerCreds, ""); code != codes.OK {
+		t.Errorf("a server with a certificate of the cluster got %v with -clientCA", code)
+	}
+}
+
+func TestReplicationWithMTLSAndClusterKey(t *testing.T) {
+	c := newTestCerts(t)
+	cfg := Config{Cert: c.serverCert, Key: c.serverKey, CA: c.clusterCA.CertFile, MTLS: true, ClusterKey: "secret"}
+	address := serveReplication(t, cfg)
+	peerCreds, err := peerCredentials(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := heartbeat(t, address, peerCreds, "secret"); code != codes.OK {
+		t.Errorf("a server with a certificate and the cluster key got %v", code)
+	}
+	if code := heartbeat(t, address, peerCreds, "wrong"); code != codes.Unauthenticated {
+		t.Errorf("a server with the wrong cluster key got %v, want %v", code, codes.Unauthenticated)
+	}
+	if code := heartbeat(t, address, bidderCredentials(t, c, "", ""), "secret"); code != codes.PermissionDenied {
+		t.Errorf("a caller with the cluster key but no certificate got %v, want %v", code, codes.PermissionDenied)
+	}
+}
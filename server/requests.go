@@ -0,0 +1,62 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+)
+
+// a bid, maximum bid or acceptance, which the client gives a request id that is the same for every retry of it
+type request interface {
+	GetId() int32
+	GetAuctionId() int32
+	GetRequestId() string
+}
+
+// a request as identified by the client
+type requestKey struct {
+	bidder int32
+	id     string
+}
+
+// the answer to an earlier request with the same request id, nil if there is none or the request has no id.
+// apply checks it as well as the leader, as a retry can be proposed before the first attempt is committed.
+// called with s.mu held
+func (s *AuctionServer) answered(in request) *proto.Ack {
+	auction, ok := s.auctions[in.GetAuctionId()]
+	if !ok || in.GetRequestId() == "" {
+		return nil
+	}
+	ack, ok := auction.requests[requestKey{bidder: in.GetId(), id: in.GetRequestId()}]
+	if !ok {
+		return nil
+	}
+	return copyAck(ack)
+}
+
+// keeps the answer to an applied request for its retries, called with s.mu held
+func (s *AuctionServer) remember(in request, ack *proto.Ack) {
+	auction, ok := s.auctions[in.GetAuctionId()]
+	if !ok || in.GetRequestId() == "" {
+		return
+	}
+	auction.remember(in.GetId(), in.GetRequestId(), ack)
+}
+
+// the answers are kept as long as the auction, as a retry can come in until it is over
+func (a *AuctionState) remember(bidder int32, requestId string, ack *proto.Ack) {
+	if a.requests == nil {
+		a.requests = make(map[requestKey]*proto.Ack)
+	}
+	a.requests[requestKey{bidder: bidder, id: requestId}] = copyAck(ack)
+}
+
+// the handlers set the lamport time of the ack they answer with, so the kept answers are never handed out themselves
+func copyAck(ack *proto.Ack) *proto.Ack {
+	return &proto.Ack{
+		Outcome: ack.Outcome,
+		EndTime: ack.EndTime,
+		Price:   ack.Price,
+		MinBid:  ack.MinBid,
+		Outbid:  ack.Outbid,
+		Reason:  ack.Reason,
+	}
+}
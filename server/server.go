@@ -3,177 +3,490 @@ package main
 import (
 	proto "AuctionServer/grpc"
 	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"net"
+	"strings"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type AuctionServer struct {
 	proto.UnimplementedAuctionServer
 
-	role    string // the role of the server, leader or backup
-	backup  proto.AuctionClient
-	state   *AuctionState
-	lamport int32
+	raft *raft // keeps the auction commands consistent between the servers
+
+	// gRPC calls the handlers concurrently, mu guards state and lamport.
+	// it is never held while calling into raft, as raft calls apply, snapshot and restore which take it
+	mu            sync.Mutex
+	auctions      map[int32]*AuctionState
+	nextAuctionId int32 // id given to the next auction that is created, the same on every server
+	lamport       int32
+	watchers      map[*watcher]struct{} // clients streaming the changes of auctions
+	bidders       map[int32]*bidder     // the registry, replicated like the auctions
+	nextBidderId  int32
+	dataDir       string // where the state is kept on disk, empty if it is only kept in memory
+	lamportLimit  int32  // the lamport clock on disk, it is written again once the clock gets there
 }
 
 type Config struct {
-	Role            string //leader or backup
-	Port            string //:xxxx
-	OtherServerPort string //localhost:xxxx
+	ID            int32         //unique within the cluster
+	Port          string        //:xxxx
+	Address       string        //localhost:xxxx, how the other servers reach this one
+	Peers         []string      //localhost:xxxx,localhost:yyyy
+	Quorum        int           //servers that must hold a bid, 0 means majority
+	Heartbeat     time.Duration //how often the leader sends heartbeats
+	SuspectAfter  time.Duration //silence before a server is suspected
+	DeadAfter     time.Duration //silence before a server is considered dead
+	Cert          string        //certificate of this server, plain text without it
+	Key           string        //private key of the certificate
+	CA            string        //CA that signed the certificates of the cluster
+	ClientCA      string        //CA the certificates of the bidders must be signed by, optional
+	MTLS          bool          //only servers with a certificate from CA may replicate
+	ClusterKey    string        //secret shared by the servers, proves a caller of Replication is one of them
+	DataDir       string        //where the write-ahead log is kept, empty keeps everything in memory
+	SnapshotEvery time.Duration //how often the state is written to a snapshot and the log compacted
 }
 
 func parseConfig() Config {
-	role := flag.String("role", "leader", "server role")
+	id := flag.Int("id", 1, "server ID, unique within the cluster")
 	port := flag.String("port", ":8080", "listen address")
-	other := flag.String("otherServer", "", "other address")
+	address := flag.String("address", "", "address the other servers reach this one on, defaults to localhost and the port")
+	peers := flag.String("peers", "", "comma separated list of the other servers")
+	quorum := flag.Int("quorum", 0, "servers (including this one) that must hold a bid, 0 means majority")
+	heartbeat := flag.Duration("heartbeat", 300*time.Millisecond, "how often the leader sends heartbeats")
+	suspectAfter := flag.Duration("suspectAfter", 900*time.Millisecond, "silence before a server is suspected")
+	deadAfter := flag.Duration("deadAfter", 1500*time.Millisecond, "silence before a server is considered dead")
+	cert := flag.String("cert", "", "certificate file, enables TLS")
+	key := flag.String("key", "", "private key file of the certificate")
+	ca := flag.String("ca", "", "CA file the certificates of the other servers are checked against")
+	clientCA := flag.String("clientCA", "", "CA file the certificates of the bidders are checked against, requires them when set")
+	mtls := flag.Bool("mtls", false, "only accept replication from servers with a certificate signed by -ca")
+	dataDir := flag.String("data-dir", "", "directory for the write-ahead log, so the state survives a restart. empty keeps it in memory only")
+	snapshotEvery := flag.Duration("snapshotEvery", 30*time.Second, "how often the state is written to a snapshot in -data-dir and the log before it dropped")
+	clusterKey := flag.String("clusterKey", "", "secret shared by the servers of the cluster, only accept replication from callers that send it")
 	flag.Parse()
-	return Config{*role, *port, *other}
+
+	if *heartbeat <= 0 || *suspectAfter <= *heartbeat || *deadAfter < *suspectAfter {
+		log.Fatalf("timeouts must satisfy 0 < heartbeat < suspectAfter <= deadAfter")
+	}
+	if (*cert == "") != (*key == "") {
+		log.Fatalf("-cert and -key must be given together")
+	}
+	if *cert == "" && (*ca != "" || *clientCA != "" || *mtls) {
+		log.Fatalf("-ca, -clientCA and -mtls need TLS, give -cert and -key")
+	}
+	if *snapshotEvery <= 0 {
+		log.Fatalf("-snapshotEvery must be positive")
+	}
+	if *clusterKey != "" && *cert == "" {
+		log.Fatalf("-clusterKey is only sent over TLS, give -cert and -key")
+	}
+	if *mtls && *ca == "" {
+		log.Fatalf("-mtls needs the -ca of the cluster")
+	}
+
+	if *address == "" {
+		*address = "localhost" + *port
+	}
+
+	var peerList []string
+	if *peers != "" {
+		peerList = strings.Split(*peers, ",")
+	}
+	//anyone who can call Replication can append to the log or depose the leader, so a cluster must prove its members
+	if len(peerList) > 0 && !*mtls && *clusterKey == "" {
+		log.Fatalf("a cluster needs -clusterKey or -mtls, so only its servers can replicate")
+	}
+
+	//default to a majority of the cluster, anything smaller would allow two leaders
+	majority := (len(peerList)+1)/2 + 1
+	if *quorum <= 0 {
+		*quorum = majority
+	}
+	if *quorum < majority || *quorum > len(peerList)+1 {
+		log.Fatalf("quorum %d must be between %d and %d for a cluster of %d servers", *quorum, majority, len(peerList)+1, len(peerList)+1)
+	}
+
+	return Config{
+		ID:            int32(*id),
+		Port:          *port,
+		Address:       *address,
+		Peers:         peerList,
+		Quorum:        *quorum,
+		Heartbeat:     *heartbeat,
+		SuspectAfter:  *suspectAfter,
+		DeadAfter:     *deadAfter,
+		Cert:          *cert,
+		Key:           *key,
+		CA:            *ca,
+		ClientCA:      *clientCA,
+		MTLS:          *mtls,
+		ClusterKey:    *clusterKey,
+		DataDir:       *dataDir,
+		SnapshotEvery: *snapshotEvery,
+	}
 }
 
-// holding replicated data
-type AuctionState struct {
-	duration      int32
-	auctionClosed bool
+// starts a new auction, the id is given when the command is applied so every server agrees on it
+func (s *AuctionServer) CreateAuction(ctx context.Context, in *proto.NewAuction) (*proto.AuctionCreated, error) {
+	if !s.raft.isLeader() {
+		return nil, notLeader(s.raft.leader())
+	}
+	//resolve the times here, so applying the command does not depend on the clock of each server
+	now := time.Now()
+	if in.StartTime == 0 {
+		in.StartTime = now.UnixMilli()
+	}
+	if in.EndTime == 0 {
+		in.EndTime = time.UnixMilli(in.StartTime).Add(time.Duration(in.Duration) * time.Second).UnixMilli()
+	}
+	if in.Item == "" || in.EndTime <= in.StartTime || in.EndTime <= now.UnixMilli() {
+		return nil, status.Error(codes.InvalidArgument, "an auction needs an item and an end time in the future after its start time")
+	}
+	if in.ExtendWithin < 0 || in.ExtendBy < 0 {
+		return nil, status.Error(codes.InvalidArgument, "the extension of an auction can not be negative")
+	}
+	if in.Mode == "" {
+		in.Mode = english
+	}
+	if in.Mode != english && in.Mode != firstPrice && in.Mode != secondPrice && in.Mode != dutch {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown mode %q, valid modes: %s, %s, %s, %s", in.Mode, english, firstPrice, secondPrice, dutch)
+	}
+	if in.Mode == dutch && (in.StartPrice <= 0 || in.PriceStep <= 0 || in.StepEvery <= 0 || in.FloorPrice < 0 || in.FloorPrice > in.StartPrice) {
+		return nil, status.Error(codes.InvalidArgument, "a dutch auction needs a positive start price, price step and step interval, and a floor price below the start price")
+	}
+	if in.ReservePrice < 0 || in.BuyNowPrice < 0 || (in.BuyNowPrice > 0 && in.BuyNowPrice < in.ReservePrice) {
+		return nil, status.Error(codes.InvalidArgument, "the reserve and buy-it-now price can not be negative, and buy-it-now can not be below the reserve")
+	}
+	if in.Mode == dutch && (in.ReservePrice > 0 || in.BuyNowPrice > 0) {
+		return nil, status.Error(codes.InvalidArgument, "a dutch auction uses its floor price instead of a reserve or buy-it-now price")
+	}
+	if in.Increment != nil && (in.Mode != english || !validIncrement(in.Increment)) {
+		return nil, status.Error(codes.InvalidArgument, "an increment is only for english auctions and is either a fixed amount, a percentage up to 1000% or tiers with positive steps sorted by price")
+	}
 
-	highestBid    int32
-	highestBidder int32
+	s.mu.Lock()
+	s.updateLamportOnReceive(in.Lamport)
+	lamport := s.lamport
+	s.mu.Unlock()
+
+	result, err := s.raft.propose(ctx, &proto.Command{Kind: &proto.Command_Create{Create: in}}, lamport)
+	if err != nil {
+		log.Printf("Auction of %q could not be created: %v", in.Item, err)
+		return nil, notReplicated(err, s.raft.leader())
+	}
+
+	created := result.(*proto.AuctionCreated)
+	s.mu.Lock()
+	s.incrementLamport()
+	created.Lamport = s.lamport
+	s.mu.Unlock()
+	return created, nil
 }
 
 func (s *AuctionServer) Bid(ctx context.Context, in *proto.Amount) (*proto.Ack, error) {
-	//check if call comes from leader or client
-	if s.role != "leader" {
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			val := md.Get("source")
-			if val[0] == "client" {
-				s.role = "leader"
-				log.Print("Changed state to leader")
-			}
-		}
+	//only the leader accepts bids, the client tries another server
+	if !s.raft.isLeader() {
+		return nil, notLeader(s.raft.leader())
 	}
 
+	s.mu.Lock()
 	s.updateLamportOnReceive(in.Lamport)
-	if s.state.auctionClosed {
-		log.Printf("Bid by %v of %d caused exception as the auction is closed", in.Id, in.Amount)
-		return &proto.Ack{Outcome: "exception"}, nil
+	if err := s.authenticate(ctx, in.Id); err != nil {
+		log.Printf("Request by %v was rejected: %v", in.Id, err)
+		s.mu.Unlock()
+		return nil, err
+	}
+	if _, ok := s.auctions[in.AuctionId]; !ok {
+		s.mu.Unlock()
+		return nil, noSuchAuction(in.AuctionId)
+	}
+	//a retry of a bid that was already applied gets the same answer, rather than being applied again
+	if ack := s.answered(in); ack != nil {
+		s.incrementLamport()
+		ack.Lamport = s.lamport
+		log.Printf("Bid by %v with request %s was already answered, sending the answer again (time=%d)", in.Id, in.RequestId, s.lamport)
+		s.mu.Unlock()
+		return answer(ack)
+	}
+	s.incrementLamport()
+	lamport := s.lamport
+	s.mu.Unlock()
+
+	//the bid only counts once a quorum of the servers holds it, apply decides the outcome.
+	//invalid bids go through the log as well, so every attempt ends up in the history
+	result, err := s.raft.propose(ctx, &proto.Command{Kind: &proto.Command_Bid{Bid: in}}, lamport)
+	if err != nil {
+		log.Printf("Bid by %v of %d caused exception as it could not be replicated: %v", in.Id, in.Amount, err)
+		return nil, notReplicated(err, s.raft.leader())
+	}
+
+	ack := result.(*proto.Ack)
+	s.mu.Lock()
+	s.incrementLamport()
+	ack.Lamport = s.lamport
+	s.mu.Unlock()
+	return answer(ack)
+}
+
+// accepts the current asking price of a dutch auction, the first acceptance to be committed wins
+func (s *AuctionServer) Accept(ctx context.Context, in *proto.Acceptance) (*proto.Ack, error) {
+	if !s.raft.isLeader() {
+		return nil, notLeader(s.raft.leader())
 	}
 
-	if in.AmountOfBids == 1 {
-		log.Printf("Bidder %v is registered and can now bid (time=%d)", in.Id, s.lamport)
+	s.mu.Lock()
+	s.updateLamportOnReceive(in.Lamport)
+	if err := s.authenticate(ctx, in.Id); err != nil {
+		log.Printf("Request by %v was rejected: %v", in.Id, err)
+		s.mu.Unlock()
+		return nil, err
+	}
+	if _, ok := s.auctions[in.AuctionId]; !ok {
+		s.mu.Unlock()
+		return nil, noSuchAuction(in.AuctionId)
+	}
+	if ack := s.answered(in); ack != nil {
+		s.incrementLamport()
+		ack.Lamport = s.lamport
+		log.Printf("Acceptance by %v with request %s was already answered, sending the answer again (time=%d)", in.Id, in.RequestId, s.lamport)
+		s.mu.Unlock()
+		return answer(ack)
 	}
+	s.incrementLamport()
+	lamport := s.lamport
+	s.mu.Unlock()
 
-	if in.Amount <= s.state.highestBid {
-		log.Printf("Bid by %v of %d fail as it was not a valid bid", in.Id, in.Amount)
-		return &proto.Ack{Outcome: "fail"}, nil
+	result, err := s.raft.propose(ctx, &proto.Command{Kind: &proto.Command_Accept{Accept: in}}, lamport)
+	if err != nil {
+		log.Printf("Acceptance by %v caused exception as it could not be replicated: %v", in.Id, err)
+		return nil, notReplicated(err, s.raft.leader())
 	}
 
-	s.state.highestBidder = in.Id
-	s.state.highestBid = in.Amount
-	log.Printf("Bid by %v of %d was successfully added to the Auction (time=%d)", in.Id, in.Amount, s.lamport)
+	ack := result.(*proto.Ack)
+	s.mu.Lock()
+	s.incrementLamport()
+	ack.Lamport = s.lamport
+	s.mu.Unlock()
+	return answer(ack)
+}
+
+// stores a secret maximum, the server then bids for the bidder whenever someone outbids them
+func (s *AuctionServer) PlaceMaxBid(ctx context.Context, in *proto.Amount) (*proto.Ack, error) {
+	if !s.raft.isLeader() {
+		return nil, notLeader(s.raft.leader())
+	}
 
-	// Update backup if leader
-	if s.role == "leader" && s.backup != nil {
+	s.mu.Lock()
+	s.updateLamportOnReceive(in.Lamport)
+	if err := s.authenticate(ctx, in.Id); err != nil {
+		log.Printf("Request by %v was rejected: %v", in.Id, err)
+		s.mu.Unlock()
+		return nil, err
+	}
+	if _, ok := s.auctions[in.AuctionId]; !ok {
+		s.mu.Unlock()
+		return nil, noSuchAuction(in.AuctionId)
+	}
+	//a retry of a bid that was already applied gets the same answer, rather than being applied again
+	if ack := s.answered(in); ack != nil {
 		s.incrementLamport()
-		//meta data
-		md := metadata.Pairs("source", "leader")
-		ctx := metadata.NewOutgoingContext(context.Background(), md)
-
-		//construct update
-		req := &proto.Amount{
-			Id:           in.Id,           //bidder ID
-			Amount:       in.Amount,       //bid amount
-			Lamport:      s.lamport,       //lamport
-			AmountOfBids: in.AmountOfBids, //amount of bids
-		}
+		ack.Lamport = s.lamport
+		log.Printf("Maximum bid by %v with request %s was already answered, sending the answer again (time=%d)", in.Id, in.RequestId, s.lamport)
+		s.mu.Unlock()
+		return answer(ack)
+	}
+	s.incrementLamport()
+	lamport := s.lamport
+	s.mu.Unlock()
 
-		Ack, err := s.backup.Bid(ctx, req)
-		if err != nil {
-			log.Printf("Backup is not responding")
-			s.backup = nil
-		}
-		log.Printf("Ack from backup was recieved: %v", Ack)
+	//the maximum is replicated, so a failover does not lose it
+	result, err := s.raft.propose(ctx, &proto.Command{Kind: &proto.Command_MaxBid{MaxBid: in}}, lamport)
+	if err != nil {
+		log.Printf("Maximum bid by %v of %d caused exception as it could not be replicated: %v", in.Id, in.Amount, err)
+		return nil, notReplicated(err, s.raft.leader())
 	}
 
+	ack := result.(*proto.Ack)
+	s.mu.Lock()
 	s.incrementLamport()
-	return &proto.Ack{Outcome: "success"}, nil
+	ack.Lamport = s.lamport
+	s.mu.Unlock()
+	return answer(ack)
 }
 
 func (s *AuctionServer) Result(ctx context.Context, in *proto.Empty) (*proto.Outcome, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.updateLamportOnReceive(in.Lamport)
+	auction, ok := s.auctions[in.AuctionId]
+	if !ok {
+		return nil, noSuchAuction(in.AuctionId)
+	}
 	s.incrementLamport()
-	return &proto.Outcome{Id: s.state.highestBidder, HighestBid: s.state.highestBid, ActionClosed: s.state.auctionClosed}, nil
+	outcome := auction.outcome()
+	outcome.Lamport = s.lamport
+	return outcome, nil
 }
 
 func main() {
 	cfg := parseConfig()
 
-	server := &AuctionServer{
-		role: cfg.Role,
+	peerCreds, err := peerCredentials(cfg)
+	if err != nil {
+		log.Fatalf("Could not load the certificates: %v", err)
 	}
 
-	auction := AuctionState{
-		duration:      50,
-		auctionClosed: false,
-		highestBid:    0,
-		highestBidder: 0,
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(peerCreds)}
+	if cfg.ClusterKey != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(clusterKey(cfg.ClusterKey)))
 	}
-	server.state = &auction
 
-	if server.role == "leader" {
-		// Make client connection to the other server
-		clientAddress := cfg.OtherServerPort
-		conn, err := grpc.NewClient(clientAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		log.Printf("Connected to %v as ", clientAddress)
+	// Make client connections to the other servers
+	var peers []*peer
+	for _, address := range cfg.Peers {
+		conn, err := grpc.NewClient(address, dialOptions...)
 		if err != nil {
-			log.Fatalf("Not working")
+			log.Fatalf("Not working: %v", err)
 		}
-		client := proto.NewAuctionClient(conn)
-		server.backup = client
-		log.Printf("Set backup to %v", server.backup)
+		peers = append(peers, &peer{address: address, client: proto.NewReplicationClient(conn), trigger: make(chan struct{}, 1)})
+		log.Printf("Added peer %v", address)
 	}
 
-	server.startServer(cfg.Port)
+	server := newAuctionServer(newRaft(cfg.ID, cfg.Address, peers, cfg.Quorum, cfg.Heartbeat, newDetector(cfg.SuspectAfter, cfg.DeadAfter)))
+	if cfg.DataDir != "" {
+		if err := server.recover(cfg.DataDir); err != nil {
+			log.Fatalf("Could not recover the state from %s: %v", cfg.DataDir, err)
+		}
+	}
+	server.raft.start()
+	if cfg.DataDir != "" {
+		go server.raft.snapshotLoop(cfg.SnapshotEvery)
+	}
+	go server.timerLoop()
+	log.Printf("Server %d joined a cluster of %d servers with a quorum of %d", cfg.ID, len(peers)+1, cfg.Quorum)
+
+	server.startServer(cfg)
+}
+
+// an auction server without auctions or bidders that replicates through r, r still has to be started
+func newAuctionServer(r *raft) *AuctionServer {
+	s := &AuctionServer{
+		auctions:      make(map[int32]*AuctionState),
+		nextAuctionId: 1,
+		watchers:      make(map[*watcher]struct{}),
+		bidders:       make(map[int32]*bidder),
+		nextBidderId:  1,
+		raft:          r,
+	}
+	r.apply = s.apply
+	r.snapshot = s.snapshot
+	r.restore = s.restore
+	return s
 }
 
-func (s *AuctionServer) startServer(port string) {
-	grpcServer := grpc.NewServer()
-	listener, err := net.Listen("tcp", port)
+func (s *AuctionServer) startServer(cfg Config) {
+	options, err := serverOptions(cfg)
+	if err != nil {
+		log.Fatalf("Could not load the certificates: %v", err)
+	}
+	grpcServer := grpc.NewServer(options...)
+	listener, err := net.Listen("tcp", cfg.Port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	replication, err := newReplicationServer(s.raft, cfg)
+	if err != nil {
+		log.Fatalf("Could not load the certificates: %v", err)
+	}
+
 	proto.RegisterAuctionServer(grpcServer, s)
+	proto.RegisterReplicationServer(grpcServer, replication)
 
-	log.Printf("Auction server listening on port %v (time=%d)", port, s.lamport)
+	s.mu.Lock()
+	log.Printf("Auction server listening on port %v with %s (time=%d)", cfg.Port, transport(cfg), s.lamport)
+	s.mu.Unlock()
 	err = grpcServer.Serve(listener)
 	if err != nil {
 		log.Fatalf("Did not work: %v", err)
 	}
 }
 
-// utility method that compares the received lamport clock with the local one and updates it if the received one is higher
+// utility method that compares the received lamport clock with the local one and updates it if the received one is higher.
+// like the other lamport utilities it must be called with the lock held
 func (s *AuctionServer) updateLamportOnReceive(remoteLamport int32) int32 {
 	if remoteLamport > s.lamport {
 		s.lamport = remoteLamport
-		s.checkLamport()
 	}
 	s.incrementLamport()
-	s.checkLamport()
 	return s.lamport
 }
 
-func (s *AuctionServer) checkLamport() {
-	if s.lamport >= s.state.duration {
-		s.state.auctionClosed = true
-	}
-}
-
 // utility method that increments the local lamport clock
 func (s *AuctionServer) incrementLamport() {
 	s.lamport++
-	s.checkLamport()
+	s.reserveLamport()
+}
+
+// utility method that makes an error with the rejection in its status details, so clients can react to the reason
+// without matching on the message
+func reject(code codes.Code, rejection *proto.Rejection, msg string) error {
+	st, err := status.New(code, msg).WithDetails(rejection)
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// utility method that answers a successful ack as it is, and anything else with an error carrying why it was rejected
+func answer(ack *proto.Ack) (*proto.Ack, error) {
+	if ack.Outcome == proto.BidOutcome_SUCCESS {
+		return ack, nil
+	}
+	rejection := &proto.Rejection{
+		Outcome: ack.Outcome,
+		Reason:  ack.Reason,
+		MinBid:  ack.MinBid,
+		EndTime: ack.EndTime,
+		Lamport: ack.Lamport,
+	}
+	return nil, reject(codes.FailedPrecondition, rejection, describe(ack.Reason))
+}
+
+// utility method that turns a reason into words, e.g. increment too small
+func describe(reason proto.Reason) string {
+	return strings.ToLower(strings.ReplaceAll(reason.String(), "_", " "))
+}
+
+// the answer to a write on a server that is not the leader. it names the leader the server follows, if it knows
+// one, so the client can go straight there instead of trying the servers one by one
+func notLeader(leader string) error {
+	msg := errNotLeader.Error()
+	if leader != "" {
+		msg += ", try " + leader
+	}
+	return reject(codes.Unavailable, &proto.Rejection{Reason: proto.Reason_NOT_LEADER, LeaderAddress: leader}, msg)
+}
+
+func noSuchAuction(id int32) error {
+	return reject(codes.NotFound, &proto.Rejection{Reason: proto.Reason_NO_SUCH_AUCTION}, fmt.Sprintf("auction %d does not exist", id))
+}
+
+// a command that was not committed in time may still be, the client finds out by asking the leader again,
+// which is the one given if this server lost the leadership in the meantime
+func notReplicated(err error, leader string) error {
+	code := codes.Unavailable
+	if errors.Is(err, context.DeadlineExceeded) {
+		code = codes.DeadlineExceeded
+	}
+	rejection := &proto.Rejection{Outcome: proto.BidOutcome_EXCEPTION, Reason: proto.Reason_NOT_COMMITTED, LeaderAddress: leader}
+	return reject(code, rejection, fmt.Sprintf("could not be replicated: %v", err))
 }
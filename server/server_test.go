@@ -0,0 +1,223 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"io"
+	"log"
+	"math"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMain(m *testing.M) {
+	// the servers log every step, which would bury the test output
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// starts a cluster of one server in-process and waits until it has elected itself
+func newTestServer(t *testing.T) *AuctionServer {
+	t.Helper()
+	s := newAuctionServer(newRaft(1, "localhost:0", nil, 1, 50*time.Millisecond, newDetector(150*time.Millisecond, 250*time.Millisecond)))
+	startTestServer(t, s)
+	return s
+}
+
+func startTestServer(t *testing.T, s *AuctionServer) {
+	t.Helper()
+	s.raft.start()
+	deadline := time.Now().Add(5 * time.Second)
+	for !s.raft.isLeader() {
+		if time.Now().After(deadline) {
+			t.Fatal("the server did not become leader")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// registers a bidder and returns its id with a context that carries its credential
+func register(t *testing.T, s *AuctionServer, name string) (int32, context.Context) {
+	t.Helper()
+	registered, err := s.Register(context.Background(), &proto.Registration{Name: name})
+	if err != nil {
+		t.Fatalf("register %s: %v", name, err)
+	}
+	return registered.Id, metadata.NewIncomingContext(context.Background(), metadata.Pairs("credential", registered.Credential))
+}
+
+// every successful attempt in the history of the auction, oldest first
+func successes(t *testing.T, s *AuctionServer, auctionId int32, kind string) []*proto.BidRecord {
+	t.Helper()
+	var records []*proto.BidRecord
+	offset := int32(0)
+	for {
+		page, err := s.History(context.Background(), &proto.HistoryRequest{AuctionId: auctionId, Offset: offset, Limit: 100})
+		if err != nil {
+			t.Fatalf("history: %v", err)
+		}
+		for _, record := range page.Records {
+			if record.Kind == kind && record.Outcome == proto.BidOutcome_SUCCESS {
+				records = append(records, record)
+			}
+		}
+		if page.NextOffset == 0 {
+			return records
+		}
+		offset = page.NextOffset
+	}
+}
+
+func TestConcurrentBids(t *testing.T) {
+	s := newTestServer(t)
+	created, err := s.CreateAuction(context.Background(), &proto.NewAuction{Item: "lamp", Duration: 60})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	auctionId := created.AuctionId
+
+	const bidders, bidsEach = 16, 25
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var accepted []int32
+	for b := range bidders {
+		id, ctx := register(t, s, "bidder")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range bidsEach {
+				// the bidders overbid each other with amounts that often collide
+				amount := int32(i*bidders/2 + b%(bidders/2) + 1)
+				ack, err := s.Bid(ctx, &proto.Amount{Id: id, Amount: amount, AuctionId: auctionId})
+				if err != nil {
+					continue
+				}
+				if ack.Outcome != proto.BidOutcome_SUCCESS {
+					t.Errorf("bid of %d was answered without an error, but with %v", amount, ack.Outcome)
+				}
+				mu.Lock()
+				accepted = append(accepted, amount)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(accepted) == 0 {
+		t.Fatal("no bid was accepted")
+	}
+	records := successes(t, s, auctionId, "bid")
+	if len(records) != len(accepted) {
+		t.Fatalf("%d bids were acknowledged, but the history holds %d successful ones", len(accepted), len(records))
+	}
+	highest := int32(0)
+	for i, record := range records {
+		if i > 0 && record.Amount <= records[i-1].Amount {
+			t.Errorf("bid %d of %d was accepted after a bid of %d", i, record.Amount, records[i-1].Amount)
+		}
+		highest = max(highest, record.Amount)
+	}
+	slices.Sort(accepted)
+	for i, amount := range accepted {
+		if amount != records[i].Amount {
+			t.Errorf("acknowledged bid of %d is not in the history", amount)
+		}
+	}
+
+	outcome, err := s.Result(context.Background(), &proto.Empty{AuctionId: auctionId})
+	if err != nil {
+		t.Fatalf("result: %v", err)
+	}
+	if outcome.HighestBid != highest {
+		t.Errorf("result has highest bid %d, the largest accepted bid was %d", outcome.HighestBid, highest)
+	}
+}
+
+func TestMaxBidWinsTieWithLaterBid(t *testing.T) {
+	s := newTestServer(t)
+	created, err := s.CreateAuction(context.Background(), &proto.NewAuction{Item: "lamp", Duration: 60})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	auctionId := created.AuctionId
+	alice, aliceCtx := register(t, s, "alice")
+	bob, bobCtx := register(t, s, "bob")
+
+	if _, err := s.PlaceMaxBid(aliceCtx, &proto.Amount{Id: alice, Amount: 100, AuctionId: auctionId}); err != nil {
+		t.Fatalf("max bid: %v", err)
+	}
+	ack, err := s.Bid(bobCtx, &proto.Amount{Id: bob, Amount: 100, AuctionId: auctionId})
+	if err != nil {
+		t.Fatalf("bid: %v", err)
+	}
+	if !ack.Outbid {
+		t.Error("a bid of the maximum placed before it was not outbid")
+	}
+
+	outcome, err := s.Result(context.Background(), &proto.Empty{AuctionId: auctionId})
+	if err != nil {
+		t.Fatalf("result: %v", err)
+	}
+	if outcome.Id != alice || outcome.HighestBid != 100 {
+		t.Errorf("bidder %d leads with %d, want bidder %d with 100", outcome.Id, outcome.HighestBid, alice)
+	}
+}
+
+func TestRetriedAcceptance(t *testing.T) {
+	s := newTestServer(t)
+	created, err := s.CreateAuction(context.Background(), &proto.NewAuction{Item: "painting", Duration: 60, Mode: dutch, StartPrice: 100, PriceStep: 10, StepEvery: 60, FloorPrice: 50})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	auctionId := created.AuctionId
+	alice, ctx := register(t, s, "alice")
+
+	in := &proto.Acceptance{Id: alice, AuctionId: auctionId, RequestId: "retried"}
+	for attempt := range 2 {
+		ack, err := s.Accept(ctx, in)
+		if err != nil {
+			t.Fatalf("attempt %d: %v", attempt, err)
+		}
+		if ack.Price != 100 {
+			t.Errorf("attempt %d bought at %d, want 100", attempt, ack.Price)
+		}
+	}
+	if records := successes(t, s, auctionId, "accept"); len(records) != 1 {
+		t.Errorf("the history holds %d acceptances, want 1", len(records))
+	}
+}
+
+func TestIncrementNearTheLargestBid(t *testing.T) {
+	s := newTestServer(t)
+	if _, err := s.CreateAuction(context.Background(), &proto.NewAuction{Item: "lamp", Duration: 60, Increment: &proto.IncrementRule{Percent: maxIncrementPercent + 1}}); err == nil {
+		t.Error("an increment above the largest percentage was accepted")
+	}
+	alice, ctx := register(t, s, "alice")
+
+	for _, rule := range []*proto.IncrementRule{{Percent: maxIncrementPercent}, {Fixed: math.MaxInt32}, nil} {
+		created, err := s.CreateAuction(context.Background(), &proto.NewAuction{Item: "lamp", Duration: 60, Increment: rule})
+		if err != nil {
+			t.Fatalf("create with %v: %v", rule, err)
+		}
+		//the increment above this bid does not fit in an int32, so no bid can follow it
+		amount := int32(math.MaxInt32 / 2)
+		if rule == nil {
+			amount = math.MaxInt32
+		}
+		if _, err := s.Bid(ctx, &proto.Amount{Id: alice, Amount: amount, AuctionId: created.AuctionId}); err != nil {
+			t.Fatalf("bid with %v: %v", rule, err)
+		}
+		outcome, err := s.Result(context.Background(), &proto.Empty{AuctionId: created.AuctionId})
+		if err != nil {
+			t.Fatalf("result with %v: %v", rule, err)
+		}
+		if outcome.MinBid != math.MaxInt32 {
+			t.Errorf("minimum next bid with %v is %d, want %d", rule, outcome.MinBid, int32(math.MaxInt32))
+		}
+	}
+}
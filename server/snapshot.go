@@ -0,0 +1,79 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// writes the applied state to the snapshot file every interval and drops the entries it covers from the log,
+// in memory and on disk, so neither keeps growing
+func (r *raft) snapshotLoop(every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for range ticker.C {
+		r.compact()
+	}
+}
+
+func (r *raft) compact() {
+	r.applyMu.Lock()
+	defer r.applyMu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.wal == nil || r.lastApplied <= r.offset {
+		return
+	}
+	snapshot := r.snapshot()
+	snapshot.LastIndex = r.lastApplied
+	snapshot.LastTerm = r.termAt(r.lastApplied)
+	//the snapshot is on disk before the log is cut, a crash in between leaves entries that replay skips
+	if err := r.wal.saveSnapshot(snapshot); err != nil {
+		log.Fatalf("Could not write the snapshot: %v", err)
+	}
+
+	dropped := snapshot.LastIndex - r.offset
+	r.log = append([]*proto.LogEntry{{Term: snapshot.LastTerm}}, r.log[snapshot.LastIndex+1-r.offset:]...)
+	r.offset = snapshot.LastIndex
+	var index int32
+	if len(r.log) > 1 {
+		index = r.offset + 1
+	}
+	if err := r.wal.rewrite(r.walRecord(index, r.log[1:], nil)); err != nil {
+		log.Fatalf("Could not compact %s: %v", r.wal.path, err)
+	}
+	log.Printf("Wrote a snapshot up to entry %d and dropped %d entries from the log (time=%d)", snapshot.LastIndex, dropped, snapshot.Lamport)
+}
+
+// replaces the snapshot file, framed with its length and a crc32 like the records of the log
+func (w *wal) saveSnapshot(snapshot *proto.Snapshot) error {
+	buf, err := frame(snapshot)
+	if err != nil {
+		return err
+	}
+	return writeFileSync(filepath.Join(w.dir, "snapshot"), buf)
+}
+
+// reads the snapshot file in the directory, nil if there is none yet
+func loadSnapshot(dir string) (*proto.Snapshot, error) {
+	path := filepath.Join(dir, "snapshot")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &proto.Snapshot{}
+	if _, err := readFrame(bytes.NewReader(data), snapshot); err != nil {
+		//it is replaced in one rename, so this is damage to the disk rather than a crash while writing it
+		return nil, fmt.Errorf("snapshot %s is damaged: %v", path, err)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+// what the connections are secured with, for the log
+func transport(cfg Config) string {
+	if cfg.Cert == "" {
+		return "plain text"
+	}
+	return "TLS"
+}
+
+// the credentials of the listener, plain text without -cert.
+// with -clientCA every bidder must show a certificate, otherwise one is only checked if it is shown
+func serverOptions(cfg Config) ([]grpc.ServerOption, error) {
+	if cfg.Cert == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	//the other servers show a certificate from the cluster CA, so it is trusted next to the one of the bidders
+	clientCAs, err := loadPool(cfg.CA, cfg.ClientCA)
+	if err != nil {
+		return nil, err
+	}
+	if clientCAs != nil {
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	if cfg.ClientCA != "" {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(config))}, nil
+}
+
+// the credentials used to reach the other servers. the servers are checked against -ca and this server shows
+// its own certificate, so it can replicate when the others run with -mtls
+func peerCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	if cfg.Cert == "" {
+		return insecure.NewCredentials(), nil
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+	if err != nil {
+		return nil, err
+	}
+	roots, err := loadPool(cfg.CA)
+	if err != nil {
+		return nil, err
+	}
+	//without -ca the other servers are checked against the system CAs
+	return credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}, RootCAs: roots, MinVersion: tls.VersionTLS12}), nil
+}
+
+// checks the certificate the caller showed in the handshake against the cluster CA
+func clusterMember(ctx context.Context, cluster *x509.CertPool) error {
+	p, ok := grpcpeer.FromContext(ctx)
+	if !ok {
+		return fmt.Errorf("unknown caller")
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return fmt.Errorf("no certificate was shown")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range info.State.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := info.State.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         cluster,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
+
+// reads the PEM certificates of the files into one pool, nil if no file is given
+func loadPool(files ...string) (*x509.CertPool, error) {
+	var pool *x509.CertPool
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates in %s", file)
+		}
+	}
+	return pool, nil
+}
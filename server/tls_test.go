@@ -0,0 +1,190 @@
+package main
+
+import (
+	"AuctionServer/internal/testpki"
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// the certificates of a cluster, and of bidders signed by a CA of their own
+type testCerts struct {
+	clusterCA, bidderCA   *testpki.CA
+	serverCert, serverKey string
+	bidderCert, bidderKey string
+}
+
+func newTestCerts(t *testing.T) testCerts {
+	dir := t.TempDir()
+	c := testCerts{clusterCA: testpki.NewCA(t, dir, "cluster"), bidderCA: testpki.NewCA(t, dir, "bidders")}
+	c.serverCert, c.serverKey = c.clusterCA.Issue(t, "server")
+	c.bidderCert, c.bidderKey = c.bidderCA.Issue(t, "alice")
+	return c
+}
+
+// serves a health service with the options of cfg and checks every caller with clusterMember. returns the address
+// and a function giving what clusterMember said about the last call
+func serveTLS(t *testing.T, cfg Config) (string, func() error) {
+	t.Helper()
+	options, err := serverOptions(cfg)
+	if err != nil {
+		t.Fatalf("server options: %v", err)
+	}
+	cluster, err := loadPool(cfg.CA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var member error
+	options = append(options, grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		mu.Lock()
+		member = clusterMember(ctx, cluster)
+		mu.Unlock()
+		return handler(ctx, req)
+	}))
+	server := grpc.NewServer(options...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return "localhost:" + port, func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return member
+	}
+}
+
+// makes one call with the credentials
+func call(t *testing.T, address string, creds credentials.TransportCredentials) error {
+	t.Helper()
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+// the credentials of a bidder that trusts the cluster CA and shows the certificate, if one is given
+func bidderCredentials(t *testing.T, c testCerts, certFile, keyFile string) credentials.TransportCredentials {
+	t.Helper()
+	roots, err := loadPool(c.clusterCA.CertFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &tls.Config{RootCAs: roots}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(config)
+}
+
+func TestPlainText(t *testing.T) {
+	cfg := Config{}
+	address, member := serveTLS(t, cfg)
+	creds, err := peerCredentials(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := call(t, address, creds); err != nil {
+		t.Fatalf("plain text call: %v", err)
+	}
+	if member() == nil {
+		t.Error("a caller without TLS was taken for a cluster member")
+	}
+}
+
+func TestTLSWithoutClientCA(t *testing.T) {
+	c := newTestCerts(t)
+	cfg := Config{Cert: c.serverCert, Key: c.serverKey, CA: c.clusterCA.CertFile}
+	address, member := serveTLS(t, cfg)
+
+	peerCreds, err := peerCredentials(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := call(t, address, peerCreds); err != nil {
+		t.Fatalf("call from another server: %v", err)
+	}
+	if err := member(); err != nil {
+		t.Errorf("another server was not taken for a cluster member: %v", err)
+	}
+
+	if err := call(t, address, bidderCredentials(t, c, "", "")); err != nil {
+		t.Fatalf("call from a bidder without a certificate: %v", err)
+	}
+	if member() == nil {
+		t.Error("a caller without a certificate was taken for a cluster member")
+	}
+
+	if call(t, address, insecure.NewCredentials()) == nil {
+		t.Error("a plain text call was served over TLS")
+	}
+	//the server only asks for certificates of the cluster CA, so one from another CA is not shown and the caller
+	//is served as a bidder without a certificate
+	if err := call(t, address, bidderCredentials(t, c, c.bidderCert, c.bidderKey)); err != nil {
+		t.Fatalf("call from a bidder with a certificate of another CA: %v", err)
+	}
+	if member() == nil {
+		t.Error("a certificate from a foreign CA was taken for a cluster member")
+	}
+}
+
+func TestTLSWithClientCA(t *testing.T) {
+	c := newTestCerts(t)
+	cfg := Config{Cert: c.serverCert, Key: c.serverKey, CA: c.clusterCA.CertFile, ClientCA: c.bidderCA.CertFile}
+	address, member := serveTLS(t, cfg)
+
+	if call(t, address, bidderCredentials(t, c, "", "")) == nil {
+		t.Error("a bidder without a certificate was served with -clientCA")
+	}
+
+	if err := call(t, address, bidderCredentials(t, c, c.bidderCert, c.bidderKey)); err != nil {
+		t.Fatalf("call from a bidder with a certificate: %v", err)
+	}
+	if member() == nil {
+		t.Error("a bidder certificate was taken for a cluster member")
+	}
+
+	peerCreds, err := peerCredentials(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := call(t, address, peerCreds); err != nil {
+		t.Fatalf("call from another server: %v", err)
+	}
+	if err := member(); err != nil {
+		t.Errorf("another server was not taken for a cluster member: %v", err)
+	}
+}
+
+func TestClusterMemberWithoutPeer(t *testing.T) {
+	c := newTestCerts(t)
+	cluster, err := loadPool(c.clusterCA.CertFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clusterMember(context.Background(), cluster) == nil {
+		t.Error("a caller that is not known was taken for a cluster member")
+	}
+}
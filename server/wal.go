@@ -0,0 +1,313 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	maxRecordSize = 64 << 20 // a larger length can only be a torn or corrupt header
+	lamportBlock  = 1000     // lamport times reserved by each write of the clock
+)
+
+var (
+	errTorn    = errors.New("torn record")    // the file ends before the record does
+	errDamaged = errors.New("damaged record") // the record is whole, but not what was written
+)
+
+// the write-ahead log of a server. every change to the term, vote, commit index and raft log is appended and
+// fsynced before the server acts on it, so a server that restarts gets its log back and applies it again.
+// each record is framed by its length and a crc32 of it, like the snapshot
+type wal struct {
+	file *os.File
+	path string
+	dir  string
+}
+
+// opens the log in the directory and reads the records in it. a record at the end that was only partly
+// written when the server went down is cut off, everything before it was fsynced and is kept. a damaged record
+// with more after it is an error, as cutting it off would drop records that were fsynced and maybe committed
+func openWAL(dir string) (*wal, []*proto.WalRecord, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, err
+	}
+	path := filepath.Join(dir, "wal")
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	//the file may just have been created, and the entry in the directory is not durable before the directory is synced
+	if err := syncDir(dir); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	records, end, err := readRecords(file)
+	if err != nil && !errors.Is(err, errTorn) {
+		file.Close()
+		return nil, nil, fmt.Errorf("%s is damaged after %d records at offset %d: %v", path, len(records), end, err)
+	}
+	if err != nil {
+		log.Printf("Cut off the end of %s after %d records: %v", path, len(records), err)
+		if err := file.Truncate(end); err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		if err := file.Sync(); err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+	}
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return &wal{file: file, path: path, dir: dir}, records, nil
+}
+
+// reads records until the end of the file or the first one that is not whole, end is the offset after the last good one.
+// the error wraps errTorn if only zeros follow the bad record, as a write that was cut short leaves it like that,
+// and some file systems fill the space it was going to take with zeros after a power loss
+func readRecords(file io.Reader) (records []*proto.WalRecord, end int64, err error) {
+	reader := bufio.NewReader(file)
+	for {
+		record := &proto.WalRecord{}
+		size, err := readFrame(reader, record)
+		if err == io.EOF {
+			return records, end, nil
+		}
+		if errors.Is(err, errDamaged) {
+			rest, readErr := io.ReadAll(reader)
+			if readErr == nil && !slices.ContainsFunc(rest, func(b byte) bool { return b != 0 }) {
+				return records, end, fmt.Errorf("%w: %v", errTorn, err)
+			}
+		}
+		if err != nil {
+			return records, end, err
+		}
+		records = append(records, record)
+		end += size
+	}
+}
+
+// appends the record and waits until it is on disk
+func (w *wal) append(record *proto.WalRecord) error {
+	buf, err := frame(record)
+	if err != nil {
+		return err
+	}
+	if _, err := w.file.Write(buf); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// replaces the whole log with the record, once a snapshot covers everything before it
+func (w *wal) rewrite(record *proto.WalRecord) error {
+	buf, err := frame(record)
+	if err != nil {
+		return err
+	}
+	if err := writeFileSync(w.path, buf); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w.file.Close()
+	w.file = file
+	return nil
+}
+
+// the message with its length and a crc32 of both in front. the length is in the checksum so a header of zeros,
+// which is what a torn write can leave, never passes as an empty record
+func frame(message protobuf.Message) ([]byte, error) {
+	data, err := protobuf.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(data)))
+	copy(buf[8:], data)
+	binary.BigEndian.PutUint32(buf[4:8], checksum(buf[0:4], data))
+	return buf, nil
+}
+
+func checksum(length []byte, data []byte) uint32 {
+	return crc32.Update(crc32.ChecksumIEEE(length), crc32.IEEETable, data)
+}
+
+// reads one framed message, io.EOF if there is nothing left and an error if it was not written whole.
+// returns the bytes it took up
+func readFrame(reader io.Reader, message protobuf.Message) (int64, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		if err == io.EOF {
+			return 0, err
+		}
+		return 0, fmt.Errorf("%w: header: %v", errTorn, err)
+	}
+	size := binary.BigEndian.Uint32(header[0:4])
+	//every record holds at least the term or a vote, and every snapshot an index
+	if size == 0 || size > maxRecordSize {
+		return 0, fmt.Errorf("%w: length %d", errDamaged, size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return 0, fmt.Errorf("%w: %v", errTorn, err)
+	}
+	if checksum(header[0:4], data) != binary.BigEndian.Uint32(header[4:8]) {
+		return 0, fmt.Errorf("%w: checksum mismatch", errDamaged)
+	}
+	if err := protobuf.Unmarshal(data, message); err != nil {
+		return 0, err
+	}
+	return int64(len(header) + len(data)), nil
+}
+
+// writes the term, vote and commit index, and the entries from index on if there are any, to the write-ahead log.
+// must be called with the lock held, before anything is sent that depends on the change
+func (r *raft) persist(index int32, entries []*proto.LogEntry, snapshot *proto.Snapshot) {
+	if r.wal == nil {
+		return
+	}
+	if err := r.wal.append(r.walRecord(index, entries, snapshot)); err != nil {
+		// carrying on would mean acknowledging what is not on disk
+		log.Fatalf("Could not write to %s: %v", r.wal.path, err)
+	}
+}
+
+// the current term, vote and commit index together with the change to the log, must be called with the lock held
+func (r *raft) walRecord(index int32, entries []*proto.LogEntry, snapshot *proto.Snapshot) *proto.WalRecord {
+	return &proto.WalRecord{
+		Term:        r.currentTerm,
+		VotedFor:    r.votedFor,
+		CommitIndex: r.commitIndex,
+		Index:       index,
+		Entries:     entries,
+		Snapshot:    snapshot,
+	}
+}
+
+// rebuilds the term, vote and log from the snapshot file, if there is one, and the write-ahead log, before the loops
+// are started. the latest snapshot is restored and the apply loop then applies the committed entries after it again.
+// the log can still hold entries the snapshot covers if the server went down while compacting, they are skipped
+func (r *raft) replay(snapshot *proto.Snapshot, records []*proto.WalRecord) {
+	if snapshot != nil {
+		r.replaceLog(snapshot)
+	}
+	for _, record := range records {
+		r.currentTerm = record.Term
+		r.votedFor = record.VotedFor
+		if record.Snapshot != nil && record.Snapshot.LastIndex > r.offset {
+			r.replaceLog(record.Snapshot)
+			snapshot = record.Snapshot
+		}
+		if record.Index > 0 {
+			index, entries := record.Index, record.Entries
+			if index <= r.offset {
+				entries = entries[min(r.offset+1-index, int32(len(entries))):]
+				index = r.offset + 1
+			}
+			r.log = append(r.log[:index-r.offset], entries...)
+		}
+		r.commitIndex = max(r.commitIndex, record.CommitIndex)
+	}
+	r.commitIndex = min(r.commitIndex, r.lastIndex())
+	if snapshot != nil {
+		r.restore(snapshot)
+		r.lastApplied = r.offset
+	}
+}
+
+// the lamport clock is written in blocks, so not every tick has to be. after a restart the clock continues from the
+// end of the last block, which no time this server has used can be past. called with s.mu held
+func (s *AuctionServer) reserveLamport() {
+	if s.dataDir == "" || s.lamport < s.lamportLimit {
+		return
+	}
+	s.lamportLimit = s.lamport + lamportBlock
+	if err := writeFileSync(filepath.Join(s.dataDir, "lamport"), []byte(strconv.Itoa(int(s.lamportLimit)))); err != nil {
+		log.Fatalf("Could not write the lamport clock: %v", err)
+	}
+}
+
+// loads the state the server had before it went down from the data directory, before raft is started
+func (s *AuctionServer) recover(dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, "lamport"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		limit, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return fmt.Errorf("lamport clock: %v", err)
+		}
+		s.lamport = int32(limit)
+		s.lamportLimit = int32(limit)
+	}
+	s.dataDir = dir
+
+	snapshot, err := loadSnapshot(dir)
+	if err != nil {
+		return err
+	}
+	w, records, err := openWAL(dir)
+	if err != nil {
+		return err
+	}
+	s.raft.replay(snapshot, records)
+	s.raft.wal = w
+	log.Printf("Recovered term %d, a snapshot up to entry %d and log entries up to %d, %d of them committed, from %s (time=%d)", s.raft.currentTerm, s.raft.offset, s.raft.lastIndex(), s.raft.commitIndex, dir, s.lamport)
+	return nil
+}
+
+// replaces the file with the data in a way that leaves either the old or the new file after a crash
+func writeFileSync(path string, data []byte) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// fsyncs the directory, so that files created in it or renamed into it are still there after a power loss
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
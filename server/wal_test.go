@@ -0,0 +1,204 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// a server of a cluster of one that keeps its state in dir
+func newDurableServer(t *testing.T, dir string) (*AuctionServer, error) {
+	t.Helper()
+	s := newAuctionServer(newRaft(1, "localhost:0", nil, 1, 50*time.Millisecond, newDetector(150*time.Millisecond, 250*time.Millisecond)))
+	if err := s.recover(dir); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// stops the server from writing to its data directory, as if it went down. its loops keep running in memory
+func crash(s *AuctionServer) {
+	s.raft.mu.Lock()
+	defer s.raft.mu.Unlock()
+	s.raft.wal.file.Close()
+	s.raft.wal = nil
+}
+
+// fills dir with a snapshot holding bids of 10 and 20 and a log after it with bids of 30 and 40
+func writeDataDir(t *testing.T, dir string) int32 {
+	t.Helper()
+	s, err := newDurableServer(t, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	startTestServer(t, s)
+	created, err := s.CreateAuction(context.Background(), &proto.NewAuction{Item: "lamp", Duration: 60})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	alice, ctx := register(t, s, "alice")
+	bid := func(amount int32) {
+		if _, err := s.Bid(ctx, &proto.Amount{Id: alice, Amount: amount, AuctionId: created.AuctionId}); err != nil {
+			t.Fatalf("bid of %d: %v", amount, err)
+		}
+	}
+	bid(10)
+	bid(20)
+	s.raft.compact()
+	bid(30)
+	bid(40)
+	crash(s)
+	return created.AuctionId
+}
+
+// the offsets at which the frames in the file start
+func frameOffsets(t *testing.T, path string) []int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var offsets []int
+	for offset := 0; offset < len(data); offset += 8 + int(binary.BigEndian.Uint32(data[offset:])) {
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+func flipByte(t *testing.T, path string, offset int) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[offset] ^= 0xff
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// recovers a server from dir and checks it has the snapshot and the bids of the log it kept
+func checkRecovered(t *testing.T, dir string, auctionId int32, highest int32) {
+	t.Helper()
+	s, err := newDurableServer(t, dir)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if s.raft.offset == 0 {
+		t.Error("the snapshot was not loaded")
+	}
+	startTestServer(t, s)
+	defer crash(s)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		outcome, err := s.Result(context.Background(), &proto.Empty{AuctionId: auctionId})
+		if err == nil && outcome.HighestBid == highest {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("highest bid after recovery is %d (%v), want %d", outcome.GetHighestBid(), err, highest)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// the log after the snapshot holds the commit index, then the entry and the new commit index of each bid
+func TestRecoverCutsOffTornTail(t *testing.T) {
+	dir := t.TempDir()
+	auctionId := writeDataDir(t, dir)
+	path := filepath.Join(dir, "wal")
+	offsets := frameOffsets(t, path)
+	//the write of the bid of 40 was cut short, so the entry is lost but the bids before it are not
+	entry := offsets[len(offsets)-2]
+	if err := os.Truncate(path, int64(entry+5)); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := newDurableServer(t, dir)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	crash(s)
+	if got := frameOffsets(t, path); len(got) != len(offsets)-2 {
+		t.Errorf("the log holds %d records after recovery, want %d", len(got), len(offsets)-2)
+	}
+	checkRecovered(t, dir, auctionId, 30)
+}
+
+func TestRecoverCutsOffDamagedLastRecord(t *testing.T) {
+	dir := t.TempDir()
+	auctionId := writeDataDir(t, dir)
+	path := filepath.Join(dir, "wal")
+	offsets := frameOffsets(t, path)
+	//the last record only moves the commit index, the entry of the bid of 40 before it is still there
+	flipByte(t, path, offsets[len(offsets)-1]+8)
+
+	checkRecovered(t, dir, auctionId, 40)
+}
+
+func TestRecoverCutsOffZeroFilledTail(t *testing.T) {
+	dir := t.TempDir()
+	auctionId := writeDataDir(t, dir)
+	s, err := newDurableServer(t, dir)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	term, votedFor := s.raft.currentTerm, s.raft.votedFor
+	crash(s)
+	//after a power loss the file can end in zeros where the records that were being written would have gone
+	file, err := os.OpenFile(filepath.Join(dir, "wal"), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write(make([]byte, 4096)); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	s, err = newDurableServer(t, dir)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if s.raft.currentTerm != term || s.raft.votedFor != votedFor {
+		t.Errorf("term %d and vote for %d after recovery, want term %d and vote for %d", s.raft.currentTerm, s.raft.votedFor, term, votedFor)
+	}
+	crash(s)
+	checkRecovered(t, dir, auctionId, 40)
+}
+
+func TestRecoverRefusesDamageBeforeTheEnd(t *testing.T) {
+	dir := t.TempDir()
+	writeDataDir(t, dir)
+	path := filepath.Join(dir, "wal")
+	offsets := frameOffsets(t, path)
+	flipByte(t, path, offsets[1]+8)
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newDurableServer(t, dir); err == nil {
+		t.Fatal("a log with a damaged record in the middle was recovered")
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(before) {
+		t.Errorf("the log was cut from %d to %d bytes", len(before), len(after))
+	}
+}
+
+func TestRecoverRefusesDamagedSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	writeDataDir(t, dir)
+	flipByte(t, filepath.Join(dir, "snapshot"), 12)
+
+	if _, err := newDurableServer(t, dir); err == nil {
+		t.Fatal("a damaged snapshot was loaded")
+	}
+}
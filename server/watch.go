@@ -0,0 +1,134 @@
+package main
+
+import (
+	proto "AuctionServer/grpc"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// a client streaming the changes of one auction, or of every auction if auctionId is 0
+type watcher struct {
+	auctionId int32
+	updates   chan *proto.Update // closed when the client could not keep up
+}
+
+// streams every change of the auction as it is applied, so it works on any server and not just the leader
+func (s *AuctionServer) Watch(in *proto.Empty, stream grpc.ServerStreamingServer[proto.Update]) error {
+	s.mu.Lock()
+	s.updateLamportOnReceive(in.Lamport)
+	if _, ok := s.auctions[in.AuctionId]; !ok && in.AuctionId != 0 {
+		s.mu.Unlock()
+		return noSuchAuction(in.AuctionId)
+	}
+	w := &watcher{auctionId: in.AuctionId, updates: make(chan *proto.Update, 64)}
+	s.watchers[w] = struct{}{}
+	log.Printf("A client is watching auction %d (time=%d)", in.AuctionId, s.lamport)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, w)
+		s.mu.Unlock()
+	}()
+
+	//sent straight away, so the client knows the watch started without waiting for the first update
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case update, ok := <-w.updates:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "too slow to keep up with the updates, watch again")
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			//nothing changes after the close, unless every auction is watched
+			if update.Kind == "closed" && in.AuctionId != 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// the auction a command changes, a new auction gets the next id
+func (s *AuctionServer) commandAuction(command *proto.Command) int32 {
+	switch kind := command.GetKind().(type) {
+	case *proto.Command_Create:
+		return s.nextAuctionId
+	case *proto.Command_Bid:
+		return kind.Bid.AuctionId
+	case *proto.Command_Close:
+		return kind.Close.AuctionId
+	case *proto.Command_Drop:
+		return kind.Drop.AuctionId
+	case *proto.Command_Accept:
+		return kind.Accept.AuctionId
+	case *proto.Command_MaxBid:
+		return kind.MaxBid.AuctionId
+	default:
+		return 0
+	}
+}
+
+// what the watchers can see of the auction before a command is applied, nil if it does not exist yet
+func (s *AuctionServer) watchedOutcome(id int32) *proto.Outcome {
+	auction, ok := s.auctions[id]
+	if !ok {
+		return nil
+	}
+	return auction.outcome()
+}
+
+// pushes how the applied command changed the auction to the watchers, called with s.mu held
+func (s *AuctionServer) notify(id int32, before *proto.Outcome) {
+	auction, ok := s.auctions[id]
+	if !ok || len(s.watchers) == 0 {
+		return
+	}
+	after := auction.outcome()
+	after.Lamport = s.lamport
+
+	var kinds []string
+	if before == nil {
+		kinds = append(kinds, "created")
+	} else {
+		//the highest bid of the other modes is only revealed by the close
+		if after.Mode == english && (after.HighestBid != before.HighestBid || after.Id != before.Id) {
+			kinds = append(kinds, "bid")
+		}
+		if after.EndTime != before.EndTime {
+			kinds = append(kinds, "extended")
+		}
+		if after.Mode == dutch && !after.ActionClosed && after.Price != before.Price {
+			kinds = append(kinds, "price")
+		}
+		if after.ActionClosed && !before.ActionClosed {
+			kinds = append(kinds, "closed")
+		}
+	}
+
+	for _, kind := range kinds {
+		update := &proto.Update{Kind: kind, Lamport: s.lamport, Outcome: after}
+		for w := range s.watchers {
+			if w.auctionId != 0 && w.auctionId != id {
+				continue
+			}
+			select {
+			case w.updates <- update:
+			default:
+				//apply never waits for a slow client, it is dropped and has to watch again
+				close(w.updates)
+				delete(s.watchers, w)
+			}
+		}
+	}
+}